@@ -1,5 +1,5 @@
 /**
- * [INPUT]: 依赖 internal/config, internal/router, internal/service, pkg/database
+ * [INPUT]: 依赖 internal/config, internal/model, internal/router, internal/service, pkg/database
  * [OUTPUT]: 无 - 程序入口
  * [POS]: 项目入口点，启动 HTTP 服务
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
@@ -17,11 +17,16 @@ import (
 	"time"
 
 	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/model"
 	"github.com/liangze/go-project/internal/router"
 	"github.com/liangze/go-project/internal/service"
 	"github.com/liangze/go-project/pkg/database"
 )
 
+// @title go-project API
+// @version 1.0.0
+// @description 统一响应结构见 dto.BaseResponse；业务错误以 code 区分
+// @BasePath /api/v1
 func main() {
 	// ════════════════════════════════════════════════════════════════════════
 	// Step 1: 初始化核心组件
@@ -34,15 +39,20 @@ func main() {
 		log.Fatalf("数据库连接失败: %v", err)
 	}
 
+	// 全新部署时建表，已有库只补齐缺失的列与索引
+	if err := database.Migrate(model.All()...); err != nil {
+		log.Fatalf("数据库迁移失败: %v", err)
+	}
+
 	// ════════════════════════════════════════════════════════════════════════
 	// Step 2: 初始化服务组
 	// ════════════════════════════════════════════════════════════════════════
-	serviceGroup := service.NewServiceGroup()
+	serviceGroup := service.NewServiceGroup(database.DB)
 
 	// ════════════════════════════════════════════════════════════════════════
 	// Step 3: 启动 HTTP 服务
 	// ════════════════════════════════════════════════════════════════════════
-	routerSetup := router.Setup(serviceGroup)
+	routerSetup := router.Setup(serviceGroup, router.BodyTypes)
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", config.GlobalConfig.Server.Port),
@@ -0,0 +1,15 @@
+/**
+ * [INPUT]: 依赖 embed, docs/openapi.json (由 mage docs 生成，勿手改)
+ * [OUTPUT]: 对外提供 OpenAPI 文档内容
+ * [POS]: docs 模块的 OpenAPI 3 文档，被 handler/docs_handler.go 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package docs
+
+import _ "embed"
+
+// OpenAPI 生成的 OpenAPI 3 文档 (JSON)
+//
+//go:embed openapi.json
+var OpenAPI []byte
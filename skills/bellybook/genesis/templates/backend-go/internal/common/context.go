@@ -0,0 +1,18 @@
+/**
+ * [INPUT]: 无外部依赖
+ * [OUTPUT]: 对外提供 CtxKeyUserID, CtxKeyRequestID, CtxKeyPlan 等上下文键常量
+ * [POS]: common 模块的 gin.Context 键定义，被 middleware, pkg/base, pkg/response 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package common
+
+// ════════════════════════════════════════════════════════════════════════════
+// gin.Context 键 - 与传输层 Header 名解耦，保持稳定
+// ════════════════════════════════════════════════════════════════════════════
+
+const (
+	CtxKeyUserID    = "user_id"
+	CtxKeyRequestID = "request_id"
+	CtxKeyPlan      = "plan" // 认证中间件写入的订阅套餐，用于限流分级
+)
@@ -1,6 +1,6 @@
 /**
  * [INPUT]: 无外部依赖
- * [OUTPUT]: 对外提供错误常量 ErrUnknown, ErrInternalProcess 等，CodeByError 函数
+ * [OUTPUT]: 对外提供错误常量 ErrUnknown, ErrInternalProcess 等，CodeByError, StatusByError 函数
  * [POS]: common 模块的错误定义，被 biz_err.go, middleware 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
@@ -18,6 +18,16 @@ const (
 	ErrUserNotFound       = "userNotFound"
 	ErrInvalidRequestData = "invalidRequestData"
 	ErrParameterRequired  = "parameterRequired"
+	ErrForbiddenOrigin    = "forbiddenOrigin"
+	ErrServiceBusy        = "serviceBusy"
+	ErrTooManyRequests    = "tooManyRequests"
+	ErrPreconditionFailed = "preconditionFailed"
+	ErrURITooLong         = "uriTooLong"
+)
+
+// BizErr.Data 中约定的键
+const (
+	KeyRetryAfter = "retry_after" // 秒，错误处理器据此写 Retry-After 头
 )
 
 // ════════════════════════════════════════════════════════════════════════════
@@ -28,6 +38,9 @@ const DefaultBizCode = 10001
 
 var errorCodeMapping = map[string]int{}
 
+// 需要真实 HTTP 状态码的错误 (协议层语义，如握手拒绝)，其余统一 200
+var errorStatusMapping = map[string]int{}
+
 func init() {
 	errorCodeMapping[ErrUnknown] = 10000
 	errorCodeMapping[ErrInternalProcess] = 10001
@@ -35,6 +48,17 @@ func init() {
 	errorCodeMapping[ErrUserNotFound] = 10004
 	errorCodeMapping[ErrInvalidRequestData] = 10009
 	errorCodeMapping[ErrParameterRequired] = 10005
+	errorCodeMapping[ErrForbiddenOrigin] = 10006
+	errorCodeMapping[ErrServiceBusy] = 10007
+	errorCodeMapping[ErrTooManyRequests] = 10008
+	errorCodeMapping[ErrPreconditionFailed] = 10010
+	errorCodeMapping[ErrURITooLong] = 10011
+
+	errorStatusMapping[ErrForbiddenOrigin] = 403
+	errorStatusMapping[ErrServiceBusy] = 503
+	errorStatusMapping[ErrTooManyRequests] = 429
+	errorStatusMapping[ErrPreconditionFailed] = 412
+	errorStatusMapping[ErrURITooLong] = 414
 }
 
 // CodeByError 根据错误ID获取错误码
@@ -44,3 +68,11 @@ func CodeByError(errId string) int {
 	}
 	return DefaultBizCode
 }
+
+// StatusByError 根据错误ID获取 HTTP 状态码
+func StatusByError(errId string) int {
+	if status, ok := errorStatusMapping[errId]; ok {
+		return status
+	}
+	return 200
+}
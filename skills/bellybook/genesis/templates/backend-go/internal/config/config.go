@@ -71,6 +71,13 @@ func Load() error {
 	// ────────────────────────────────────────────────────────────────────────
 	applyEnvOverrides(config)
 
+	if err := config.Server.Validate(); err != nil {
+		return err
+	}
+	if err := config.CORS.Validate(); err != nil {
+		return err
+	}
+
 	GlobalConfig = config
 	return nil
 }
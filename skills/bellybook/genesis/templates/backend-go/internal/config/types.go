@@ -1,25 +1,54 @@
 /**
- * [INPUT]: 无外部依赖
- * [OUTPUT]: 对外提供 Config, ServerConfig, AppConfig, DatabaseConfig 结构体
+ * [INPUT]: 依赖 errors, fmt, net, strings, time
+ * [OUTPUT]: 对外提供 Config, ServerConfig, AppConfig, DatabaseConfig, LogConfig, StaticConfig, DocsConfig, CORSConfig, RateLimitConfig 结构体, ErrCORSWildcardCredentials
  * [POS]: config 模块的类型定义，被 config.go 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
 
 package config
 
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
 // ════════════════════════════════════════════════════════════════════════════
 // Config 应用配置结构
 // ════════════════════════════════════════════════════════════════════════════
 
 type Config struct {
-	Environment string         `yaml:"environment"`
-	Server      ServerConfig   `yaml:"server"`
-	App         AppConfig      `yaml:"app"`
-	Database    DatabaseConfig `yaml:"database"`
+	Environment string          `yaml:"environment"`
+	Server      ServerConfig    `yaml:"server"`
+	App         AppConfig       `yaml:"app"`
+	Database    DatabaseConfig  `yaml:"database"`
+	Log         LogConfig       `yaml:"log"`
+	Static      StaticConfig    `yaml:"static"`
+	Docs        DocsConfig      `yaml:"docs"`
+	CORS        CORSConfig      `yaml:"cors"`
+	RateLimit   RateLimitConfig `yaml:"rate_limit"`
 }
 
 type ServerConfig struct {
-	Port int `yaml:"port"`
+	Port            int      `yaml:"port"`
+	RequestIDHeader string   `yaml:"request_id_header"` // 默认 X-Request-ID
+	MaxURILength    int      `yaml:"max_uri_length"`    // path+query 上限，默认 8192
+	TrustedProxies  []string `yaml:"trusted_proxies"`   // 可信反向代理 IP/CIDR，仅其转发的 X-Forwarded-For 生效；默认不信任任何代理
+}
+
+// Validate 可信代理须为合法 IP 或 CIDR，在配置加载时暴露错误
+func (c ServerConfig) Validate() error {
+	for _, p := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(p); err == nil {
+			continue
+		}
+		if net.ParseIP(p) == nil {
+			return fmt.Errorf("config: server.trusted_proxies: invalid IP or CIDR %q", p)
+		}
+	}
+	return nil
 }
 
 type AppConfig struct {
@@ -34,4 +63,87 @@ type DatabaseConfig struct {
 	Name     string `yaml:"name"`
 	User     string `yaml:"user"`
 	Password string `yaml:"password"`
+
+	PoolWaitTimeout time.Duration `yaml:"pool_wait_timeout"` // 获取连接的最长等待，超时快速失败 (默认 500ms)
+}
+
+type LogConfig struct {
+	Body            bool     `yaml:"body"`             // 是否记录请求体 (脱敏后)
+	SensitiveFields []string `yaml:"sensitive_fields"` // 类型未知时按名单脱敏的字段
+}
+
+type StaticConfig struct {
+	Enabled   bool   `yaml:"enabled"`    // 纯 API 部署保持关闭
+	Dir       string `yaml:"dir"`        // 前端构建产物目录，如 web/dist
+	AssetsDir string `yaml:"assets_dir"` // Dir 下带内容哈希的资源目录，长期缓存，默认 assets
+}
+
+type DocsConfig struct {
+	Enabled bool `yaml:"enabled"` // 挂载 /api/v1/docs (Swagger UI + OpenAPI)，生产环境保持关闭
+}
+
+type CORSConfig struct {
+	AllowOrigins     []string `yaml:"allow_origins"`     // 为空或含 "*" 时 HTTP 放行所有来源；WebSocket 只认显式来源
+	AllowCredentials bool     `yaml:"allow_credentials"` // 携带 Cookie 时必须配置显式来源
+}
+
+// 凭证模式下不允许通配来源
+var ErrCORSWildcardCredentials = errors.New(`config: cors.allow_credentials requires explicit allow_origins (not empty or "*")`)
+
+// Wildcard 白名单为空或含 "*"
+func (c CORSConfig) Wildcard() bool {
+	if len(c.AllowOrigins) == 0 {
+		return true
+	}
+	for _, o := range c.AllowOrigins {
+		if o == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate 凭证模式下拒绝通配来源，在配置加载时暴露错误
+func (c CORSConfig) Validate() error {
+	if c.AllowCredentials && c.Wildcard() {
+		return ErrCORSWildcardCredentials
+	}
+	return nil
+}
+
+// ListsOrigin 来源是否显式列在白名单中 ("*" 不算)
+func (c CORSConfig) ListsOrigin(origin string) bool {
+	for _, o := range c.AllowOrigins {
+		if o != "*" && strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsOrigin HTTP CORS 是否放行该来源
+// 通配仅在不带凭证时生效；带凭证时只放行显式来源
+func (c CORSConfig) AllowsOrigin(origin string) bool {
+	if c.ListsOrigin(origin) {
+		return true
+	}
+	return c.Wildcard() && !c.AllowCredentials
+}
+
+// RateLimitConfig 分级限流，tiers 键为档位名 (anonymous / authenticated / premium)
+//
+//	rate_limit:
+//	  enabled: true
+//	  tiers:
+//	    anonymous:     { rps: 2,  burst: 10 }
+//	    authenticated: { rps: 10, burst: 40 }
+//	    premium:       { rps: 50, burst: 100 }
+type RateLimitConfig struct {
+	Enabled bool                 `yaml:"enabled"`
+	Tiers   map[string]TierLimit `yaml:"tiers"`
+}
+
+type TierLimit struct {
+	RPS   float64 `yaml:"rps"`   // 每秒补充令牌数
+	Burst int     `yaml:"burst"` // 桶容量
 }
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCORSConfigValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  CORSConfig
+		want error
+	}{
+		{"通配无凭证", CORSConfig{}, nil},
+		{"显式来源 + 凭证", CORSConfig{AllowOrigins: []string{"https://app.example.com"}, AllowCredentials: true}, nil},
+		{"空白名单 + 凭证", CORSConfig{AllowCredentials: true}, ErrCORSWildcardCredentials},
+		{"\"*\" + 凭证", CORSConfig{AllowOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true}, ErrCORSWildcardCredentials},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.cfg.Validate(); !errors.Is(err, tc.want) {
+				t.Fatalf("Validate() = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestServerConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		proxies []string
+		wantErr bool
+	}{
+		{"默认不信任代理", nil, false},
+		{"IP 与 CIDR", []string{"10.0.0.1", "172.16.0.0/12", "::1"}, false},
+		{"非法条目", []string{"10.0.0.1", "lb.internal"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ServerConfig{TrustedProxies: tc.proxies}.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
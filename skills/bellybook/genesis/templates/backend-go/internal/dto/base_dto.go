@@ -1,6 +1,6 @@
 /**
- * [INPUT]: 依赖 github.com/google/uuid
- * [OUTPUT]: 对外提供 ResponseCode, BaseResponse, BasePageRequest, BaseIdReq 及响应构造器
+ * [INPUT]: 依赖 encoding/base64, encoding/json, github.com/google/uuid
+ * [OUTPUT]: 对外提供 ResponseCode, BaseResponse, BasePageRequest, PageCursor, PageResponse, BaseIdReq 及响应构造器
  * [POS]: dto 模块的基础结构，被所有 handler 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
@@ -8,6 +8,9 @@
 package dto
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -99,9 +102,11 @@ func NotFoundResponse(resource string) *BaseResponse {
 // ════════════════════════════════════════════════════════════════════════════
 
 // BasePageRequest 分页请求基类
+// Cursor 非空时走游标 (keyset) 分页，忽略 Page；为空时按 Page 偏移分页
 type BasePageRequest struct {
-	Page     int `json:"page" binding:"omitempty,min=1"`
-	PageSize int `json:"page_size" binding:"omitempty,min=1,max=100"`
+	Page     int    `json:"page" form:"page" binding:"omitempty,min=1"`
+	PageSize int    `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `json:"cursor" form:"cursor"`
 }
 
 // Normalize 标准化分页参数
@@ -122,6 +127,50 @@ func (p *BasePageRequest) GetOffset() int {
 	return (p.Page - 1) * p.PageSize
 }
 
+// ────────────────────────────────────────────────────────────────────────────
+// PageCursor 游标内容：上一页最后一条的排序键 + 主键 (打破排序键并列)
+// 对外只暴露不透明字符串，客户端不应解析
+// ────────────────────────────────────────────────────────────────────────────
+
+type PageCursor struct {
+	SortKey string `json:"k"`
+	ID      string `json:"id"`
+}
+
+var ErrInvalidCursor = errors.New("dto: invalid page cursor")
+
+// EncodeCursor 编码为 URL 安全的不透明字符串
+func EncodeCursor(c PageCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor 解析游标，空字符串返回 nil
+func DecodeCursor(s string) (*PageCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c PageCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// PageResponse 分页响应；NextCursor 为空表示没有下一页
+// Total 仅偏移分页时返回，游标分页不做 COUNT
+type PageResponse[T any] struct {
+	List       []T    `json:"list"`
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 // BaseIdReq 基础 ID 请求
 type BaseIdReq struct {
 	Id uuid.UUID `json:"id" binding:"required"`
@@ -0,0 +1,16 @@
+/**
+ * [INPUT]: 无外部依赖
+ * [OUTPUT]: 对外提供 UpdateProfileReq
+ * [POS]: dto 模块的用户请求结构，被 handler/user_handler.go, service/user_service.go 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package dto
+
+// ════════════════════════════════════════════════════════════════════════════
+// UpdateProfileReq 更新用户信息 (PATCH 语义，nil 字段不修改)
+// ════════════════════════════════════════════════════════════════════════════
+
+type UpdateProfileReq struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=64"`
+}
@@ -1,5 +1,5 @@
 /**
- * [INPUT]: 依赖 internal/service, pkg/base, github.com/gin-gonic/gin
+ * [INPUT]: 依赖 internal/dto, internal/service, pkg/base, github.com/gin-gonic/gin
  * [OUTPUT]: 对外提供 UserHandler, NewUserHandler()
  * [POS]: handler 模块的用户处理器，被 router 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
@@ -9,6 +9,7 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/dto"
 	"github.com/liangze/go-project/internal/service"
 	"github.com/liangze/go-project/pkg/base"
 )
@@ -29,20 +30,120 @@ func NewUserHandler(svc *service.UserService) *UserHandler {
 // GetProfile 获取用户信息
 // @Summary 获取当前用户信息
 // @Tags User
-// @Success 200 {object} dto.BaseResponse
+// @Produce json
+// @Param If-None-Match header string false "上次返回的 ETag，未变更时返回 304"
+// @Success 200 {object} dto.BaseResponse{data=service.UserProfile}
+// @Header 200 {string} ETag "当前版本，用于 If-Match / If-None-Match"
+// @Success 304 "未变更"
 // @Router /user/profile/detail [get]
 // ════════════════════════════════════════════════════════════════════════════
-
 func (h *UserHandler) GetProfile(c *gin.Context) error {
 	userID, err := base.MustAuth(c)
 	if err != nil {
 		return err
 	}
 
-	user, err := h.svc.GetByID(userID)
+	user, err := h.svc.GetByID(c.Request.Context(), userID)
 	if err != nil {
 		return err // 直接透传 Service 层 BizErr
 	}
 
+	base.SetETag(c, base.VersionETag(user.ID, user.Version))
+	return base.OK(c, user)
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UpdateProfile 更新用户信息 (If-Match 乐观并发)
+// @Summary 更新当前用户信息
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param If-Match header string false "GetProfile 返回的 ETag"
+// @Param body body dto.UpdateProfileReq true "更新内容"
+// @Success 200 {object} dto.BaseResponse{data=service.UserProfile}
+// @Header 200 {string} ETag "更新后的版本"
+// @Router /user/profile [patch]
+// ════════════════════════════════════════════════════════════════════════════
+func (h *UserHandler) UpdateProfile(c *gin.Context) error {
+	userID, err := base.MustAuth(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateProfileReq
+	if err := base.MustBind(c, &req); err != nil {
+		return err
+	}
+
+	current, err := h.svc.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		return err
+	}
+	if err := base.CheckIfMatch(c, base.VersionETag(current.ID, current.Version)); err != nil {
+		return err
+	}
+
+	user, err := h.svc.UpdateProfile(c.Request.Context(), userID, current.Version, &req)
+	if err != nil {
+		return err
+	}
+
+	base.SetETag(c, base.VersionETag(user.ID, user.Version))
 	return base.OK(c, user)
 }
+
+// ════════════════════════════════════════════════════════════════════════════
+// GetStats 获取用户聚合统计 (需登录)
+// @Summary 获取用户统计 (总数/活跃/近7天新增)
+// @Tags User
+// @Produce json
+// @Param If-None-Match header string false "上次返回的 ETag，未变更时返回 304"
+// @Success 200 {object} dto.BaseResponse{data=service.UserStats}
+// @Header 200 {string} ETag "响应体的弱 ETag"
+// @Success 304 "未变更"
+// @Router /user/stats [get]
+// ════════════════════════════════════════════════════════════════════════════
+func (h *UserHandler) GetStats(c *gin.Context) error {
+	if _, err := base.MustAuth(c); err != nil {
+		return err
+	}
+
+	stats, err := h.svc.Stats(c.Request.Context())
+	if err != nil {
+		return err
+	}
+
+	return base.OK(c, stats)
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// ListUsers 用户列表 (游标分页，需登录；列表项不含邮箱)
+// @Summary 用户列表，按注册时间倒序
+// @Tags User
+// @Produce json
+// @Param cursor query string false "上一页返回的 next_cursor；传入时忽略 page"
+// @Param page query int false "页码，未传 cursor 时按偏移分页并返回 total"
+// @Param page_size query int false "每页条数"
+// @Param If-None-Match header string false "上次返回的 ETag，未变更时返回 304"
+// @Success 200 {object} dto.BaseResponse{data=dto.PageResponse[service.UserSummary]}
+// @Header 200 {string} ETag "响应体的弱 ETag"
+// @Success 304 "未变更"
+// @Router /user/list [get]
+// ════════════════════════════════════════════════════════════════════════════
+func (h *UserHandler) ListUsers(c *gin.Context) error {
+	if _, err := base.MustAuth(c); err != nil {
+		return err
+	}
+
+	var req dto.BasePageRequest
+	if err := base.MustBindQuery(c, &req); err != nil {
+		return err
+	}
+
+	page, err := h.svc.List(c.Request.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return base.OK(c, page)
+}
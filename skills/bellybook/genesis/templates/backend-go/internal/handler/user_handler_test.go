@@ -0,0 +1,221 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/dto"
+	"github.com/liangze/go-project/internal/model"
+	"github.com/liangze/go-project/internal/service"
+	"github.com/liangze/go-project/internal/testutil"
+	"github.com/liangze/go-project/pkg/base"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// GetProfile 经完整中间件链 + 内存数据库的端到端测试
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestGetProfile(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	uid := uuid.New()
+	testutil.Seed(t, db, &model.User{ID: uid, Name: "Alice", Email: "a@example.com", Active: true})
+
+	api := testutil.Client(testutil.NewEngine(t, db))
+	resp := api.As(uid).Get(t, "/api/v1/user/profile/detail", dto.CodeSuccess)
+
+	var profile service.UserProfile
+	resp.Bind(t, &profile)
+	if profile.ID != uid || profile.Name != "Alice" || profile.Email != "a@example.com" {
+		t.Fatalf("profile = %+v", profile)
+	}
+	if etag, want := resp.HTTP.Header().Get("ETag"), base.VersionETag(uid, 1); etag != want {
+		t.Fatalf("ETag = %q, want %q", etag, want)
+	}
+	if resp.RequestID == "" {
+		t.Fatal("request_id 为空，RequestID 中间件未生效")
+	}
+}
+
+// 用户私有响应：ETag 绑定用户，他人的 ETag 不会换来 304，且禁止共享缓存
+func TestGetProfileETagPerUser(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	alice, bob := uuid.New(), uuid.New()
+	testutil.Seed(t, db,
+		&model.User{ID: alice, Name: "Alice", Email: "a@example.com", Active: true},
+		&model.User{ID: bob, Name: "Bob", Email: "b@example.com", Active: true},
+	)
+	engine := testutil.NewEngine(t, db)
+	api := testutil.Client(engine)
+
+	first := api.As(alice).Get(t, "/api/v1/user/profile/detail", dto.CodeSuccess)
+	aliceTag := first.HTTP.Header().Get("ETag")
+	if cc := first.HTTP.Header().Get("Cache-Control"); cc != "private, no-cache" {
+		t.Fatalf("Cache-Control = %q, want %q", cc, "private, no-cache")
+	}
+	if vary := first.HTTP.Header().Get("Vary"); vary != "Authorization" {
+		t.Fatalf("Vary = %q, want Authorization", vary)
+	}
+
+	var profile service.UserProfile
+	api.As(bob).With("If-None-Match", aliceTag).Get(t, "/api/v1/user/profile/detail", dto.CodeSuccess).Bind(t, &profile)
+	if profile.ID != bob {
+		t.Fatalf("携带他人 ETag 拿到 %+v, want Bob", profile)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/user/profile/detail", nil)
+	req.Header.Set(testutil.HeaderTestUser, alice.String())
+	req.Header.Set("If-None-Match", aliceTag)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("本人 ETag status = %d, want 304", rec.Code)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "private, no-cache" {
+		t.Fatalf("304 Cache-Control = %q, want %q", cc, "private, no-cache")
+	}
+}
+
+func TestGetProfileUnauthorized(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+
+	api := testutil.Client(testutil.NewEngine(t, db))
+	api.Get(t, "/api/v1/user/profile/detail", dto.ResponseCode(common.CodeByError(common.ErrUnauthorized)))
+}
+
+func TestGetProfileNotFound(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+
+	api := testutil.Client(testutil.NewEngine(t, db))
+	api.As(uuid.New()).Get(t, "/api/v1/user/profile/detail", dto.ResponseCode(common.CodeByError(common.ErrUserNotFound)))
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// ListUsers 需登录，列表项不暴露邮箱
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestListUsersRequiresAuth(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	testutil.Seed(t, db, &model.User{ID: uuid.New(), Name: "Alice", Email: "a@example.com", Active: true})
+
+	api := testutil.Client(testutil.NewEngine(t, db))
+	resp := api.Get(t, "/api/v1/user/list", dto.ResponseCode(common.CodeByError(common.ErrUnauthorized)))
+	if len(resp.Data) != 0 {
+		t.Fatalf("未登录时不应返回数据: %s", resp.Data)
+	}
+}
+
+func TestListUsersOmitsEmail(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	uid := uuid.New()
+	testutil.Seed(t, db, &model.User{ID: uid, Name: "Alice", Email: "a@example.com", Active: true})
+
+	api := testutil.Client(testutil.NewEngine(t, db))
+	resp := api.As(uid).Get(t, "/api/v1/user/list", dto.CodeSuccess)
+
+	if strings.Contains(string(resp.Data), "a@example.com") {
+		t.Fatalf("列表泄露邮箱: %s", resp.Data)
+	}
+	var page dto.PageResponse[service.UserSummary]
+	resp.Bind(t, &page)
+	if len(page.List) != 1 || page.List[0].ID != uid {
+		t.Fatalf("list = %+v", page.List)
+	}
+}
+
+func TestListUsersPagination(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	base := time.Now()
+	ids := make([]uuid.UUID, 5) // ids[0] 最新
+	for i := range ids {
+		ids[i] = uuid.New()
+		testutil.Seed(t, db, &model.User{ID: ids[i], Name: "u", Email: ids[i].String(), CreatedAt: base.Add(-time.Duration(i) * time.Minute)})
+	}
+	api := testutil.Client(testutil.NewEngine(t, db)).As(ids[0])
+
+	// 偏移分页：第 2 页返回第 3、4 条，并带总数与后续游标
+	var page dto.PageResponse[service.UserSummary]
+	api.Get(t, "/api/v1/user/list?page=2&page_size=2", dto.CodeSuccess).Bind(t, &page)
+	if len(page.List) != 2 || page.List[0].ID != ids[2] || page.List[1].ID != ids[3] {
+		t.Fatalf("page 2 = %+v", page.List)
+	}
+	if page.Total == nil || *page.Total != 5 {
+		t.Fatalf("total = %v, want 5", page.Total)
+	}
+
+	// 游标分页：从第 2 页的游标继续，只剩最后一条，不返回 total
+	var next dto.PageResponse[service.UserSummary]
+	api.Get(t, "/api/v1/user/list?page_size=2&cursor="+page.NextCursor, dto.CodeSuccess).Bind(t, &next)
+	if len(next.List) != 1 || next.List[0].ID != ids[4] || next.NextCursor != "" || next.Total != nil {
+		t.Fatalf("cursor page = %+v", next)
+	}
+
+	api.Get(t, "/api/v1/user/list?cursor=garbage", dto.ResponseCode(common.CodeByError(common.ErrInvalidRequestData)))
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// GetStats 聚合统计：总数 / 活跃 / 近 7 天新增
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestGetStats(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	recentActive, recentInactive, oldActive := uuid.New(), uuid.New(), uuid.New()
+	testutil.Seed(t, db,
+		&model.User{ID: recentActive, Name: "a", Email: "a@example.com", Active: true},
+		&model.User{ID: recentInactive, Name: "b", Email: "b@example.com"},
+		&model.User{ID: oldActive, Name: "c", Email: "c@example.com", Active: true, CreatedAt: time.Now().AddDate(0, 0, -30)},
+	)
+	if err := db.Model(&model.User{}).Where("id = ?", recentInactive).Update("active", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	api := testutil.Client(testutil.NewEngine(t, db))
+	api.Get(t, "/api/v1/user/stats", dto.ResponseCode(common.CodeByError(common.ErrUnauthorized)))
+
+	var stats service.UserStats
+	api.As(recentActive).Get(t, "/api/v1/user/stats", dto.CodeSuccess).Bind(t, &stats)
+	want := service.UserStats{Total: 3, Active: 2, CreatedLast7: 2}
+	if stats != want {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UpdateProfile If-Match 乐观并发：过期版本 412 且不写入，当前版本更新并递增 ETag
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestUpdateProfileIfMatch(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	uid := uuid.New()
+	testutil.Seed(t, db, &model.User{ID: uid, Name: "Alice", Email: "a@example.com", Active: true})
+
+	api := testutil.Client(testutil.NewEngine(t, db)).As(uid)
+	body := dto.UpdateProfileReq{Name: ptr("Bob")}
+
+	for _, stale := range []string{base.VersionETag(uid, 99), base.VersionETag(uuid.New(), 1)} {
+		resp := api.With("If-Match", stale).Patch(t, "/api/v1/user/profile", body, dto.ResponseCode(common.CodeByError(common.ErrPreconditionFailed)))
+		if resp.HTTP.Code != 412 {
+			t.Fatalf("If-Match %s: status = %d, want 412", stale, resp.HTTP.Code)
+		}
+	}
+	var profile service.UserProfile
+	api.Get(t, "/api/v1/user/profile/detail", dto.CodeSuccess).Bind(t, &profile)
+	if profile.Name != "Alice" || profile.Version != 1 {
+		t.Fatalf("412 后数据被修改: %+v", profile)
+	}
+
+	resp := api.With("If-Match", base.VersionETag(uid, 1)).Patch(t, "/api/v1/user/profile", body, dto.CodeSuccess)
+	resp.Bind(t, &profile)
+	if profile.Name != "Bob" || profile.Version != 2 {
+		t.Fatalf("profile = %+v, want Bob v2", profile)
+	}
+	if etag, want := resp.HTTP.Header().Get("ETag"), base.VersionETag(uid, 2); etag != want {
+		t.Fatalf("ETag = %q, want %q", etag, want)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,68 @@
+/**
+ * [INPUT]: 依赖 internal/common, internal/config, pkg/redact, github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 BodyLogger 中间件, BodyTypes
+ * [POS]: middleware 的请求体调试日志，被 router 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/pkg/redact"
+)
+
+// 单次记录的请求体上限，超出部分不读入日志
+const maxLoggedBody = 64 << 10
+
+// ════════════════════════════════════════════════════════════════════════════
+// BodyTypes 路由 (c.FullPath()) -> 请求体类型，用于按 sensitive tag 脱敏
+// 用法: BodyTypes{"/api/v1/user/login": dto.LoginReq{}}
+// ════════════════════════════════════════════════════════════════════════════
+
+type BodyTypes map[string]any
+
+// ════════════════════════════════════════════════════════════════════════════
+// BodyLogger 记录脱敏后的请求体，原文永不落日志
+// ════════════════════════════════════════════════════════════════════════════
+
+func BodyLogger(cfg config.LogConfig, types BodyTypes) gin.HandlerFunc {
+	r := redact.New(cfg.SensitiveFields)
+
+	resolved := make(map[string]reflect.Type, len(types))
+	for path, v := range types {
+		resolved[path] = reflect.TypeOf(v)
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		// 读取前缀后拼回原 Body，下游 handler 不受影响
+		head, err := io.ReadAll(io.LimitReader(c.Request.Body, maxLoggedBody))
+		c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(head), c.Request.Body), c.Request.Body}
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		log.Printf("[body] %s %s %s %s", c.GetString(common.CtxKeyRequestID),
+			c.Request.Method, c.Request.URL.Path, r.JSON(head, resolved[c.FullPath()]))
+		c.Next()
+	}
+}
+
+// readCloser 组合拼接后的 Reader 与原 Body 的 Close
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
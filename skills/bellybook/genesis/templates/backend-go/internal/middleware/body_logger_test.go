@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/middleware"
+)
+
+type loginReq struct {
+	Email string `json:"email"`
+	Code  string `json:"code" sensitive:"true"`
+}
+
+// 按路由登记的类型走 sensitive tag 脱敏，下游 handler 仍读到原始请求体
+func TestBodyLoggerRedactsRegisteredType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(prev) })
+
+	const body = `{"email":"a@example.com","code":"739201"}`
+	var seen string
+
+	r := gin.New()
+	r.Use(middleware.BodyLogger(config.LogConfig{}, middleware.BodyTypes{"/login": loginReq{}}))
+	r.POST("/login", func(c *gin.Context) {
+		raw, _ := io.ReadAll(c.Request.Body)
+		seen = string(raw)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body)))
+
+	if strings.Contains(logs.String(), "739201") {
+		t.Fatalf("日志泄露敏感字段: %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), `"code":"******"`) {
+		t.Fatalf("日志未脱敏 code: %s", logs.String())
+	}
+	if seen != body {
+		t.Fatalf("handler 读到 %q, want %q", seen, body)
+	}
+}
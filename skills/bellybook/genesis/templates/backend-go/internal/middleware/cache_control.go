@@ -0,0 +1,26 @@
+/**
+ * [INPUT]: 依赖 github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 Private 中间件
+ * [POS]: middleware 的缓存策略，被 router 挂在需登录的只读路由上
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// Private 标记响应为用户私有：共享缓存不得存储，浏览器每次须带 ETag 重新验证
+// Vary: Authorization 防止同一浏览器切换账号后命中上一用户的缓存
+// 与 ETag 搭配时须挂在 ETag 之前，304 响应同样带上这些头
+// ════════════════════════════════════════════════════════════════════════════
+
+func Private() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "private, no-cache")
+		c.Writer.Header().Add("Vary", "Authorization")
+		c.Next()
+	}
+}
@@ -1,5 +1,5 @@
 /**
- * [INPUT]: 依赖 github.com/gin-gonic/gin
+ * [INPUT]: 依赖 internal/config, github.com/gin-gonic/gin
  * [OUTPUT]: 对外提供 CORS 中间件
  * [POS]: middleware 的跨域处理器，被 router 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
@@ -7,17 +7,36 @@
 
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/config"
+)
 
 // ════════════════════════════════════════════════════════════════════════════
 // CORS 跨域中间件
+// 通配且不带凭证时返回 "*"；其余情况仅回写白名单内的具体来源，
+// 带凭证时通配不生效 (永不对任意来源回写 Allow-Credentials)
 // ════════════════════════════════════════════════════════════════════════════
 
-func CORS() gin.HandlerFunc {
+func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+	wildcard := cfg.Wildcard() && !cfg.AllowCredentials
+
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, Accept-Language")
+		origin := c.GetHeader("Origin")
+
+		switch {
+		case wildcard:
+			c.Header("Access-Control-Allow-Origin", "*")
+		case origin != "" && cfg.AllowsOrigin(origin):
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+			if cfg.AllowCredentials {
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
+		}
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PATCH, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, Accept-Language, If-Match, If-None-Match")
+		c.Header("Access-Control-Expose-Headers", "ETag")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/middleware"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const (
+		allowed = "https://app.example.com"
+		evil    = "https://evil.example.net"
+	)
+	explicit := []string{allowed}
+
+	cases := []struct {
+		name       string
+		cfg        config.CORSConfig
+		origin     string
+		wantOrigin string
+		wantCreds  string
+	}{
+		{"通配无凭证", config.CORSConfig{}, evil, "*", ""},
+		{"白名单内来源", config.CORSConfig{AllowOrigins: explicit}, allowed, allowed, ""},
+		{"白名单外来源", config.CORSConfig{AllowOrigins: explicit}, evil, "", ""},
+		{"凭证 + 白名单内来源", config.CORSConfig{AllowOrigins: explicit, AllowCredentials: true}, allowed, allowed, "true"},
+		{"凭证 + 白名单外来源", config.CORSConfig{AllowOrigins: explicit, AllowCredentials: true}, evil, "", ""},
+		{"凭证 + 空白名单不回写任意来源", config.CORSConfig{AllowCredentials: true}, evil, "", ""},
+		{"凭证 + \"*\" 不回写任意来源", config.CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true}, evil, "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.CORS(tc.cfg))
+			r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Origin", tc.origin)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tc.wantOrigin {
+				t.Errorf("Allow-Origin = %q, want %q", got, tc.wantOrigin)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != tc.wantCreds {
+				t.Errorf("Allow-Credentials = %q, want %q", got, tc.wantCreds)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ package middleware
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/liangze/go-project/internal/common"
@@ -28,7 +29,8 @@ func GlobalErrorHandler(c *gin.Context) {
 
 	c.Next()
 
-	// 处理 c.Error() 写入的错误
+	// 处理未经 Wrap 的 handler 通过 c.Error() 写入的错误
+	// Wrap 已直接处理并写出响应，此处不会重复处理
 	if len(c.Errors) > 0 && !c.Writer.Written() {
 		handleError(c, c.Errors.Last().Err)
 	}
@@ -39,18 +41,27 @@ func GlobalErrorHandler(c *gin.Context) {
 // ════════════════════════════════════════════════════════════════════════════
 
 func handleError(c *gin.Context, r any) {
+	c.Abort()
+
+	// 响应已写出 (handler 先写后返回 error)，不再追加响应体
+	if c.Writer.Written() {
+		return
+	}
+
 	// 优先处理 BizErr
 	var bizErr *common.BizErr
 	if err, ok := r.(error); ok && errors.As(err, &bizErr) {
 		code := common.CodeByError(bizErr.MessageId)
+		status := common.StatusByError(bizErr.MessageId)
+		if secs, ok := bizErr.Data[common.KeyRetryAfter].(int); ok {
+			c.Header("Retry-After", strconv.Itoa(secs))
+		}
 		// TODO: 接入 i18n 翻译
-		c.Abort()
-		response.Custom(c, nil, bizErr.MessageId, code)
+		response.CustomStatus(c, status, nil, bizErr.MessageId, code)
 		return
 	}
 
 	// 兜底处理
 	code := common.CodeByError(common.ErrInternalProcess)
-	c.Abort()
 	response.Custom(c, nil, "服务器内部错误", code)
 }
@@ -0,0 +1,121 @@
+/**
+ * [INPUT]: 依赖 github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 ETag 中间件
+ * [POS]: middleware 的条件请求支持 (If-None-Match -> 304)，被 router 挂在只读路由上
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// ETag 为 GET/HEAD 的 200 响应补齐 ETag，并按 If-None-Match 返回 304
+// handler 已通过 base.SetETag 写出 ETag 时沿用之，否则按响应体生成弱 ETag
+// 响应体先缓冲再摘要，勿挂在流式/WebSocket 路由上
+// ════════════════════════════════════════════════════════════════════════════
+
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		// 业务错误同样以 200 返回，但 handleError 会 Abort，不参与条件请求
+		if w.Status() != http.StatusOK || c.IsAborted() {
+			w.ResponseWriter.Write(w.buf.Bytes())
+			return
+		}
+
+		etag := w.Header().Get("ETag")
+		if etag == "" {
+			etag = weakETag(w.buf.Bytes())
+			w.Header().Set("ETag", etag)
+		}
+
+		if noneMatch(c.GetHeader("If-None-Match"), etag) {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Length")
+			w.ResponseWriter.WriteHeader(http.StatusNotModified)
+			w.ResponseWriter.WriteHeaderNow()
+			return
+		}
+		w.ResponseWriter.Write(w.buf.Bytes())
+	}
+}
+
+// bufferedWriter 暂存响应体，状态码仍记录在原 Writer 上
+// Written/Size 以缓冲为准，handleError 据此判断响应是否已写出，避免追加第二个响应体
+type bufferedWriter struct {
+	gin.ResponseWriter
+	buf     bytes.Buffer
+	written bool
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.buf.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.buf.WriteString(s)
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written || w.ResponseWriter.Written()
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return w.ResponseWriter.Size()
+	}
+	return w.buf.Len()
+}
+
+// weakETag 对响应体摘要；统一响应只取 code/message/data，
+// timestamp 与 request_id 每次请求都不同，不参与计算
+func weakETag(body []byte) string {
+	var resp struct {
+		Code    json.RawMessage `json:"code"`
+		Message json.RawMessage `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(body, &resp) == nil && resp.Code != nil {
+		body, _ = json.Marshal(resp)
+	}
+
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// noneMatch If-None-Match 采用弱比较 (RFC 9110)，"*" 匹配任意实体
+func noneMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+
+	current := strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
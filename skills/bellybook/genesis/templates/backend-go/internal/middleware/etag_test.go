@@ -0,0 +1,159 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/middleware"
+	"github.com/liangze/go-project/internal/testutil"
+	"github.com/liangze/go-project/pkg/base"
+	"github.com/liangze/go-project/pkg/response"
+)
+
+func newETagEngine() *gin.Engine {
+	r := newErrorEngine()
+	r.Use(testutil.FakeAuth(), middleware.ETag())
+
+	r.GET("/weak", func(c *gin.Context) { response.Success(c, gin.H{"n": 1}) })
+	r.GET("/strong", func(c *gin.Context) {
+		base.SetETag(c, `"fixed"`)
+		response.Success(c, gin.H{"n": 1})
+	})
+	r.GET("/error", middleware.Wrap(func(c *gin.Context) error {
+		return common.Err(common.ErrUserNotFound)
+	}))
+	r.GET("/fail", func(c *gin.Context) { c.String(http.StatusInternalServerError, "boom") })
+	r.GET("/me", middleware.Wrap(func(c *gin.Context) error {
+		userID, err := base.MustAuth(c)
+		if err != nil {
+			return err
+		}
+		base.SetETag(c, base.VersionETag(userID, 1))
+		return base.OK(c, userID)
+	}))
+	return r
+}
+
+func serveETag(r http.Handler, path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// 200 补齐 ETag；If-None-Match 命中返回空体 304
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestETagNotModified(t *testing.T) {
+	r := newETagEngine()
+
+	cases := []struct {
+		name     string
+		path     string
+		wantWeak bool
+	}{
+		{"按响应体生成弱 ETag", "/weak", true},
+		{"沿用 handler 写出的强 ETag", "/strong", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			first := serveETag(r, tc.path, nil)
+			etag := first.Header().Get("ETag")
+			if first.Code != http.StatusOK || first.Body.Len() == 0 {
+				t.Fatalf("首次请求 status = %d, body = %q", first.Code, first.Body.String())
+			}
+			if strings.HasPrefix(etag, `W/"`) != tc.wantWeak {
+				t.Fatalf("ETag = %q, wantWeak = %v", etag, tc.wantWeak)
+			}
+			if !tc.wantWeak && etag != `"fixed"` {
+				t.Fatalf("ETag = %q, want %q", etag, `"fixed"`)
+			}
+
+			again := serveETag(r, tc.path, map[string]string{"If-None-Match": etag})
+			if again.Code != http.StatusNotModified {
+				t.Fatalf("status = %d, want 304", again.Code)
+			}
+			if again.Body.Len() != 0 {
+				t.Fatalf("304 响应体非空: %q", again.Body.String())
+			}
+			if ct := again.Header().Get("Content-Type"); ct != "" {
+				t.Fatalf("304 Content-Type = %q, want 空", ct)
+			}
+		})
+	}
+}
+
+// 弱 ETag 不受 timestamp / request_id 影响，两次请求一致
+func TestETagStableAcrossRequests(t *testing.T) {
+	r := newETagEngine()
+
+	a := serveETag(r, "/weak", nil).Header().Get("ETag")
+	b := serveETag(r, "/weak", nil).Header().Get("ETag")
+	if a == "" || a != b {
+		t.Fatalf("两次 ETag 不一致: %q vs %q", a, b)
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// 业务错误 (Abort) 与非 200 响应原样透传，不生成 ETag、不返回 304
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestETagPassThrough(t *testing.T) {
+	r := newETagEngine()
+
+	cases := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"业务错误 (Abort)", "/error", common.StatusByError(common.ErrUserNotFound), `"code":`},
+		{"非 200", "/fail", http.StatusInternalServerError, "boom"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serveETag(r, tc.path, map[string]string{"If-None-Match": "*"})
+			if rec.Code != tc.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantCode)
+			}
+			if !strings.Contains(rec.Body.String(), tc.wantBody) {
+				t.Fatalf("body = %q, want 包含 %q", rec.Body.String(), tc.wantBody)
+			}
+			if etag := rec.Header().Get("ETag"); etag != "" {
+				t.Fatalf("ETag = %q, want 空", etag)
+			}
+		})
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// 用户私有资源：他人的 ETag 不命中
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestETagCrossUser(t *testing.T) {
+	r := newETagEngine()
+	alice, bob := uuid.NewString(), uuid.NewString()
+
+	aliceTag := serveETag(r, "/me", map[string]string{testutil.HeaderTestUser: alice}).Header().Get("ETag")
+
+	rec := serveETag(r, "/me", map[string]string{testutil.HeaderTestUser: bob, "If-None-Match": aliceTag})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), bob) {
+		t.Fatalf("携带他人 ETag: status = %d, body = %q, want 200 + 本人数据", rec.Code, rec.Body.String())
+	}
+
+	rec = serveETag(r, "/me", map[string]string{testutil.HeaderTestUser: alice, "If-None-Match": aliceTag})
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("本人 ETag status = %d, want 304", rec.Code)
+	}
+}
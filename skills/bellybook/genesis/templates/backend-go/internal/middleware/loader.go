@@ -0,0 +1,25 @@
+/**
+ * [INPUT]: 依赖 pkg/loader, github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 Loaders 中间件
+ * [POS]: middleware 的请求级批量加载器注入，被 router 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/pkg/loader"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// Loaders 为每个请求挂载独立的 Loader 注册表
+// service/repository 通过 loader.For(ctx, ...) 取用，缓存随请求结束释放
+// ════════════════════════════════════════════════════════════════════════════
+
+func Loaders() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(loader.WithRegistry(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,135 @@
+/**
+ * [INPUT]: 依赖 internal/common, internal/config, github.com/gin-gonic/gin, golang.org/x/time/rate
+ * [OUTPUT]: 对外提供 RateLimit 中间件, Tier, TierResolver, DefaultTierResolver
+ * [POS]: middleware 的分级限流器，被 router 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/config"
+	"golang.org/x/time/rate"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// Tier 限流档位
+// ════════════════════════════════════════════════════════════════════════════
+
+type Tier string
+
+const (
+	TierAnonymous     Tier = "anonymous"
+	TierAuthenticated Tier = "authenticated"
+	TierPremium       Tier = "premium"
+)
+
+// 未配置 anonymous 档位时的兜底限额 (最严格)
+var fallbackLimit = config.TierLimit{RPS: 1, Burst: 5}
+
+const (
+	limiterIdleTTL   = 10 * time.Minute
+	limiterSweepTick = time.Minute
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// TierResolver 根据请求决定档位
+// DefaultTierResolver: plan=premium -> premium，已认证 -> authenticated，否则 anonymous
+// ════════════════════════════════════════════════════════════════════════════
+
+type TierResolver func(c *gin.Context) Tier
+
+func DefaultTierResolver(c *gin.Context) Tier {
+	if _, ok := c.Get(common.CtxKeyUserID); !ok {
+		return TierAnonymous
+	}
+	if c.GetString(common.CtxKeyPlan) == string(TierPremium) {
+		return TierPremium
+	}
+	return TierAuthenticated
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// RateLimit 按档位限流，计数键为 档位 + 用户ID (匿名用客户端 IP)
+// 客户端 IP 取自 c.ClientIP()，engine 须配置可信代理，否则 X-Forwarded-For 可被伪造
+// 超限返回 ErrTooManyRequests (429 + Retry-After)
+// ════════════════════════════════════════════════════════════════════════════
+
+func RateLimit(cfg config.RateLimitConfig, resolve TierResolver) gin.HandlerFunc {
+	store := &limiterStore{entries: make(map[string]*limiterEntry), lastSweep: time.Now()}
+
+	return func(c *gin.Context) {
+		tier := resolve(c)
+		limit := tierLimit(cfg, tier)
+
+		key := string(tier) + ":" + c.ClientIP()
+		if userID, ok := c.Get(common.CtxKeyUserID); ok {
+			key = fmt.Sprintf("%s:%v", tier, userID)
+		}
+
+		if !store.get(key, limit).Allow() {
+			retryAfter := int(math.Ceil(1 / limit.RPS))
+			handleError(c, common.ErrWith(common.ErrTooManyRequests, common.KVPair{
+				common.KeyRetryAfter: max(retryAfter, 1),
+			}))
+			return
+		}
+		c.Next()
+	}
+}
+
+// tierLimit 未知档位或未配置时回落到 anonymous
+func tierLimit(cfg config.RateLimitConfig, tier Tier) config.TierLimit {
+	if l, ok := cfg.Tiers[string(tier)]; ok && l.RPS > 0 {
+		return l
+	}
+	if l, ok := cfg.Tiers[string(TierAnonymous)]; ok && l.RPS > 0 {
+		return l
+	}
+	return fallbackLimit
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// limiterStore 进程内令牌桶集合，定期清理空闲条目
+// ────────────────────────────────────────────────────────────────────────────
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type limiterStore struct {
+	mu        sync.Mutex
+	entries   map[string]*limiterEntry
+	lastSweep time.Time
+}
+
+func (s *limiterStore) get(key string, limit config.TierLimit) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > limiterSweepTick {
+		for k, e := range s.entries {
+			if now.Sub(e.lastSeen) > limiterIdleTTL {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	e, ok := s.entries[key]
+	if !ok {
+		e = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(limit.RPS), max(limit.Burst, 1))}
+		s.entries[key] = e
+	}
+	e.lastSeen = now
+	return e.limiter
+}
@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/middleware"
+	"github.com/liangze/go-project/internal/testutil"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// 匿名与已认证分属不同档位：各自按档位 burst 放行，超限返回 429 + Retry-After
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestRateLimitTiers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Tiers: map[string]config.TierLimit{
+			string(middleware.TierAnonymous):     {RPS: 0.5, Burst: 1},
+			string(middleware.TierAuthenticated): {RPS: 0.5, Burst: 3},
+		},
+	}
+	r := gin.New()
+	r.Use(testutil.FakeAuth(), middleware.RateLimit(cfg, middleware.DefaultTierResolver))
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if userID != "" {
+			req.Header.Set(testutil.HeaderTestUser, userID)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	alice, bob := uuid.NewString(), uuid.NewString()
+	cases := []struct {
+		name      string
+		userID    string
+		wantCode  int
+		wantRetry string
+	}{
+		{"匿名 burst 内放行", "", http.StatusOK, ""},
+		{"匿名超出 burst", "", http.StatusTooManyRequests, "2"},
+		{"同 IP 已认证用户不受匿名额度影响", alice, http.StatusOK, ""},
+		{"已认证 burst 内放行 (2)", alice, http.StatusOK, ""},
+		{"已认证 burst 内放行 (3)", alice, http.StatusOK, ""},
+		{"已认证超出 burst", alice, http.StatusTooManyRequests, "2"},
+		{"其他用户独立计数", bob, http.StatusOK, ""},
+	}
+
+	// 顺序执行：各用例依赖前序请求消耗的令牌
+	for _, tc := range cases {
+		rec := get(tc.userID)
+		if rec.Code != tc.wantCode {
+			t.Fatalf("%s: status = %d, want %d", tc.name, rec.Code, tc.wantCode)
+		}
+		if got := rec.Header().Get("Retry-After"); got != tc.wantRetry {
+			t.Fatalf("%s: Retry-After = %q, want %q", tc.name, got, tc.wantRetry)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+/**
+ * [INPUT]: 依赖 internal/common, github.com/gin-gonic/gin, github.com/google/uuid
+ * [OUTPUT]: 对外提供 RequestID 中间件, DefaultRequestIDHeader
+ * [POS]: middleware 的请求 ID 注入，被 router 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/common"
+)
+
+const (
+	DefaultRequestIDHeader = "X-Request-ID"
+	maxRequestIDLen        = 128
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// RequestID 读取/生成请求 ID，写入 Context 与响应头
+// header 为空时使用 X-Request-ID；Context 键固定为 common.CtxKeyRequestID
+// ════════════════════════════════════════════════════════════════════════════
+
+func RequestID(header string) gin.HandlerFunc {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+
+	return func(c *gin.Context) {
+		id := c.GetHeader(header)
+		if id == "" || len(id) > maxRequestIDLen {
+			id = uuid.NewString()
+		}
+
+		c.Set(common.CtxKeyRequestID, id)
+		c.Header(header, id)
+		c.Next()
+	}
+}
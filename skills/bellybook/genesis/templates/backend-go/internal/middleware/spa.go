@@ -0,0 +1,93 @@
+/**
+ * [INPUT]: 依赖 internal/config, github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 SPA 静态资源处理器
+ * [POS]: middleware 的前端静态资源 & SPA 回退，被 router 作为 NoRoute 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/config"
+)
+
+// 构建工具输出带内容哈希文件的默认目录 (Vite: dist/assets)
+const defaultAssetsDir = "assets"
+
+const (
+	cacheImmutable  = "public, max-age=31536000, immutable"
+	cacheRevalidate = "no-cache"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// SPA 提供静态目录，未命中的前端路由回退到 index.html
+// /api/* 与 /health 不处理，保持 gin 默认 404
+// 仅 AssetsDir 下的文件 (文件名含内容哈希) 长期缓存，其余每次协商
+// ════════════════════════════════════════════════════════════════════════════
+
+func SPA(cfg config.StaticConfig) gin.HandlerFunc {
+	root, err := filepath.Abs(cfg.Dir)
+	if err != nil {
+		root = cfg.Dir
+	}
+	index := filepath.Join(root, "index.html")
+
+	assetsDir := cfg.AssetsDir
+	if assetsDir == "" {
+		assetsDir = defaultAssetsDir
+	}
+	assetsPrefix := path.Clean("/"+assetsDir) + "/"
+
+	return func(c *gin.Context) {
+		p := c.Request.URL.Path
+		if isBackendPath(p) {
+			return
+		}
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			return
+		}
+
+		// ─────────────────────────────────────────────────────────────────────
+		// Step 1: 命中真实文件直接返回
+		// ─────────────────────────────────────────────────────────────────────
+		clean := path.Clean("/" + p)
+		name := filepath.Join(root, filepath.FromSlash(clean))
+		if info, err := os.Stat(name); err == nil && !info.IsDir() {
+			c.Header("Cache-Control", cacheControl(clean, assetsPrefix))
+			c.File(name)
+			return
+		}
+
+		// 缺失的资源文件返回 404，避免把 HTML 当 JS/CSS 下发
+		if path.Ext(p) != "" {
+			return
+		}
+
+		// ─────────────────────────────────────────────────────────────────────
+		// Step 2: 前端路由回退到 index.html
+		// ─────────────────────────────────────────────────────────────────────
+		c.Header("Cache-Control", cacheRevalidate)
+		c.File(index)
+	}
+}
+
+// isBackendPath 后端自有路由，不参与 SPA 回退
+func isBackendPath(p string) bool {
+	return p == "/api" || strings.HasPrefix(p, "/api/") || p == "/health"
+}
+
+// cacheControl 哈希资源目录下的文件长期缓存，其余 (含 index.html) 每次协商
+// 不按文件名猜测哈希：vue3-components.js 之类的未哈希文件若被长期缓存，发版后无法更新
+func cacheControl(clean, assetsPrefix string) string {
+	if strings.HasPrefix(clean, assetsPrefix) {
+		return cacheImmutable
+	}
+	return cacheRevalidate
+}
@@ -0,0 +1,95 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/middleware"
+)
+
+const spaIndex = "<!doctype html><div id=app></div>"
+
+// newSPADir 生成一份最小构建产物目录
+func newSPADir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":                spaIndex,
+		"assets/index-BxKqPzWe.js":  "console.log(1)",
+		"static/app.js":             "console.log(2)",
+		"vue3-components.js":        "console.log(3)",
+		"i18n-messages.json":        "{}",
+		"favicon.ico":               "ico",
+		"assets/nested/logo-a1.svg": "<svg/>",
+	}
+	for name, body := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// 缓存策略：仅哈希资源目录长期缓存；前端路由回退 index.html；后端路径与缺失资源 404
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestSPA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := newSPADir(t)
+
+	const (
+		immutable  = "public, max-age=31536000, immutable"
+		revalidate = "no-cache"
+	)
+
+	cases := []struct {
+		name      string
+		assetsDir string
+		path      string
+		wantCode  int
+		wantCache string
+		wantIndex bool
+	}{
+		{"哈希资源目录长期缓存", "", "/assets/index-BxKqPzWe.js", http.StatusOK, immutable, false},
+		{"哈希资源子目录长期缓存", "", "/assets/nested/logo-a1.svg", http.StatusOK, immutable, false},
+		{"名含数字的未哈希文件每次协商", "", "/vue3-components.js", http.StatusOK, revalidate, false},
+		{"i18n 文件每次协商", "", "/i18n-messages.json", http.StatusOK, revalidate, false},
+		{"根目录静态文件每次协商", "", "/favicon.ico", http.StatusOK, revalidate, false},
+		{"根路径返回 index.html 并每次协商", "", "/", http.StatusOK, revalidate, true},
+		{"自定义资源目录", "static", "/static/app.js", http.StatusOK, immutable, false},
+		{"自定义资源目录外不长期缓存", "static", "/assets/index-BxKqPzWe.js", http.StatusOK, revalidate, false},
+		{"前端路由回退 index.html", "", "/dashboard/settings", http.StatusOK, revalidate, true},
+		{"API 路径不回退", "", "/api/v1/missing", http.StatusNotFound, "", false},
+		{"缺失的 JS 返回 404", "", "/assets/missing-Abc12345.js", http.StatusNotFound, "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.NoRoute(middleware.SPA(config.StaticConfig{Enabled: true, Dir: dir, AssetsDir: tc.assetsDir}))
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if rec.Code != tc.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.wantCode)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tc.wantCache {
+				t.Fatalf("Cache-Control = %q, want %q", got, tc.wantCache)
+			}
+			if isIndex := rec.Body.String() == spaIndex; isIndex != tc.wantIndex {
+				t.Fatalf("body = %q, wantIndex = %v", rec.Body.String(), tc.wantIndex)
+			}
+		})
+	}
+}
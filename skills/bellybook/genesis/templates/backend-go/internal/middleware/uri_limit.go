@@ -0,0 +1,40 @@
+/**
+ * [INPUT]: 依赖 internal/common, github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 URILimit 中间件, DefaultMaxURILength
+ * [POS]: middleware 的超长 URL 拦截，被 router 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/common"
+)
+
+// 默认上限：宽松但有限，覆盖常见浏览器/代理的 URL 长度
+const DefaultMaxURILength = 8192
+
+// ════════════════════════════════════════════════════════════════════════════
+// URILimit 拒绝 path+query 超过上限的请求 (ErrURITooLong -> 414)
+// maxLen <= 0 时使用 DefaultMaxURILength
+// ════════════════════════════════════════════════════════════════════════════
+
+func URILimit(maxLen int) gin.HandlerFunc {
+	if maxLen <= 0 {
+		maxLen = DefaultMaxURILength
+	}
+
+	return func(c *gin.Context) {
+		uri := c.Request.RequestURI
+		if uri == "" {
+			uri = c.Request.URL.RequestURI()
+		}
+
+		if len(uri) > maxLen {
+			handleError(c, common.Err(common.ErrURITooLong))
+			return
+		}
+		c.Next()
+	}
+}
@@ -1,5 +1,5 @@
 /**
- * [INPUT]: 依赖 github.com/gin-gonic/gin
+ * [INPUT]: 依赖 github.com/gin-gonic/gin, error_handler.go 的 handleError
  * [OUTPUT]: 对外提供 Wrap 函数
  * [POS]: middleware 的 Handler 包装器，被 router 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
@@ -12,12 +12,15 @@ import "github.com/gin-gonic/gin"
 // ════════════════════════════════════════════════════════════════════════════
 // Wrap 将返回 error 的 handler 转换为 gin.HandlerFunc
 // 用法: router.POST("/create", middleware.Wrap(h.Create))
+//
+// 返回的 error 立即中断链路并直接交给 handleError，不经过 c.Errors 累积，
+// 即使 handler 先前调用过 c.Error()，最终也只处理这一个返回值
 // ════════════════════════════════════════════════════════════════════════════
 
 func Wrap(fn func(*gin.Context) error) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if err := fn(c); err != nil {
-			c.Error(err) // 交给 GlobalErrorHandler 处理
+			handleError(c, err)
 		}
 	}
 }
@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/dto"
+	"github.com/liangze/go-project/internal/middleware"
+	"github.com/liangze/go-project/pkg/response"
+)
+
+func newErrorEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.GlobalErrorHandler)
+	return r
+}
+
+// serveOne 发起请求并断言响应体恰好是一个 BaseResponse
+func serveOne(t *testing.T, r http.Handler, path string) dto.BaseResponse {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	dec := json.NewDecoder(bytes.NewReader(rec.Body.Bytes()))
+	var resp dto.BaseResponse
+	if err := dec.Decode(&resp); err != nil {
+		t.Fatalf("响应体不是 BaseResponse: %v (%s)", err, rec.Body.String())
+	}
+	if dec.More() {
+		t.Fatalf("响应体包含多个 JSON: %s", rec.Body.String())
+	}
+	return resp
+}
+
+func codeOf(errID string) dto.ResponseCode {
+	return dto.ResponseCode(common.CodeByError(errID))
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Wrap 只处理 handler 返回的 error，先前 c.Error() 累积的错误不参与
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestWrapReturnedErrorWinsOverCErrors(t *testing.T) {
+	r := newErrorEngine()
+	r.GET("/", middleware.Wrap(func(c *gin.Context) error {
+		_ = c.Error(errors.New("earlier"))
+		_ = c.Error(common.Err(common.ErrInvalidRequestData))
+		return common.Err(common.ErrUserNotFound)
+	}))
+
+	if resp := serveOne(t, r, "/"); resp.Code != codeOf(common.ErrUserNotFound) {
+		t.Fatalf("code = %d, want %d", resp.Code, codeOf(common.ErrUserNotFound))
+	}
+}
+
+func TestUnwrappedCErrorHandledOnce(t *testing.T) {
+	r := newErrorEngine()
+	r.GET("/", func(c *gin.Context) {
+		_ = c.Error(common.Err(common.ErrUserNotFound))
+	})
+
+	if resp := serveOne(t, r, "/"); resp.Code != codeOf(common.ErrUserNotFound) {
+		t.Fatalf("code = %d, want %d", resp.Code, codeOf(common.ErrUserNotFound))
+	}
+}
+
+// handler 已写出响应后再返回 error：保留已写出的响应，不追加错误响应体
+func TestWrapErrorAfterWrite(t *testing.T) {
+	handler := middleware.Wrap(func(c *gin.Context) error {
+		_ = c.Error(errors.New("earlier"))
+		response.Success(c, "done")
+		return common.Err(common.ErrUserNotFound)
+	})
+
+	r := newErrorEngine()
+	r.GET("/plain", handler)
+	r.GET("/etag", middleware.ETag(), handler) // ETag 缓冲响应体，Written() 须以缓冲为准
+
+	for _, path := range []string{"/plain", "/etag"} {
+		t.Run(path, func(t *testing.T) {
+			if resp := serveOne(t, r, path); resp.Code != dto.CodeSuccess {
+				t.Fatalf("code = %d, want %d", resp.Code, dto.CodeSuccess)
+			}
+		})
+	}
+}
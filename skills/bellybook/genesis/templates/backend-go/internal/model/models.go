@@ -0,0 +1,15 @@
+/**
+ * [INPUT]: 依赖 user.go 等模型定义
+ * [OUTPUT]: 对外提供 All()
+ * [POS]: model 模块的模型注册表，被 cmd/api/main.go 启动迁移消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package model
+
+// All 参与启动自动迁移的全部模型，新增模型时在此登记
+func All() []any {
+	return []any{
+		&User{},
+	}
+}
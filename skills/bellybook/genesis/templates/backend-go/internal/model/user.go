@@ -0,0 +1,37 @@
+/**
+ * [INPUT]: 依赖 gorm.io/gorm, github.com/google/uuid
+ * [OUTPUT]: 对外提供 User 模型
+ * [POS]: model 模块的用户数据模型，被 repository 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// User 用户模型
+// ════════════════════════════════════════════════════════════════════════════
+
+type User struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_users_created_id,priority:2"`
+	CreatedAt time.Time `gorm:"index:idx_users_created_id,priority:1"` // 游标分页按 (created_at, id) 扫描
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Name   string `gorm:"size:64;not null"`
+	Email  string `gorm:"size:128;uniqueIndex"`
+	Active bool   `gorm:"not null;default:true;index"`
+
+	Version int64 `gorm:"not null;default:1"` // 乐观锁版本号，每次更新 +1
+}
+
+// TableName 表名
+func (User) TableName() string {
+	return "users"
+}
@@ -0,0 +1,188 @@
+/**
+ * [INPUT]: 依赖 internal/dto, internal/model, pkg/database, pkg/loader, gorm.io/gorm, github.com/google/uuid
+ * [OUTPUT]: 对外提供 UserRepository, NewUserRepository(), UserCounts, UserPage, FindByID(), UpdateVersioned(), List(), Loader()
+ * [POS]: repository 模块的用户数据访问层，被 service/user_service.go 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/dto"
+	"github.com/liangze/go-project/internal/model"
+	"github.com/liangze/go-project/pkg/database"
+	"github.com/liangze/go-project/pkg/loader"
+	"gorm.io/gorm"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// UserRepository 用户数据访问
+// ════════════════════════════════════════════════════════════════════════════
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// ErrVersionConflict 乐观锁冲突：记录已被他人更新
+var ErrVersionConflict = errors.New("repository: version conflict")
+
+// ════════════════════════════════════════════════════════════════════════════
+// FindByID 按主键查询，不存在返回 gorm.ErrRecordNotFound
+// ════════════════════════════════════════════════════════════════════════════
+
+func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	db, release, err := database.Acquire(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var user model.User
+	if err := db.First(&user, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UpdateVersioned 乐观锁更新：仅当当前版本等于 version 时生效，版本号 +1
+// 版本不匹配返回 ErrVersionConflict；不修改调用方传入的 updates
+// ════════════════════════════════════════════════════════════════════════════
+
+func (r *UserRepository) UpdateVersioned(ctx context.Context, id uuid.UUID, version int64, updates map[string]any) error {
+	db, release, err := database.Acquire(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	set := make(map[string]any, len(updates)+1)
+	maps.Copy(set, updates)
+	set["version"] = gorm.Expr("version + 1")
+
+	res := db.Model(&model.User{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(set)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UserPage 一页用户及下一页游标；Total 仅偏移分页时填充
+// ════════════════════════════════════════════════════════════════════════════
+
+type UserPage struct {
+	Users []model.User
+	Next  string
+	Total *int64
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// List 按创建时间倒序分页
+// cursor 非空时走游标分页并忽略 offset；否则按 offset 偏移并统计总数
+// 两种方式均返回下一页游标，客户端可从任意偏移页切换到游标分页
+// 游标无法解析时返回 dto.ErrInvalidCursor
+// ════════════════════════════════════════════════════════════════════════════
+
+func (r *UserRepository) List(ctx context.Context, cursor *dto.PageCursor, offset, limit int) (*UserPage, error) {
+	var after *database.KeysetAfter
+	if cursor != nil {
+		createdAt, err := time.Parse(time.RFC3339Nano, cursor.SortKey)
+		if err != nil {
+			return nil, dto.ErrInvalidCursor
+		}
+		id, err := uuid.Parse(cursor.ID)
+		if err != nil {
+			return nil, dto.ErrInvalidCursor
+		}
+		after = &database.KeysetAfter{Sort: createdAt, ID: id}
+		offset = 0
+	}
+
+	db, release, err := database.Acquire(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	page := &UserPage{}
+	if after == nil {
+		var total int64
+		if err := db.Model(&model.User{}).Count(&total).Error; err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	var rows []model.User
+	if err := db.Scopes(database.Keyset("created_at", after, limit)).Offset(offset).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	page.Users, page.Next = database.NextPage(rows, limit, func(u model.User) dto.PageCursor {
+		return dto.PageCursor{SortKey: u.CreatedAt.Format(time.RFC3339Nano), ID: u.ID.String()}
+	})
+	return page, nil
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UserCounts 用户聚合计数
+// ════════════════════════════════════════════════════════════════════════════
+
+type UserCounts struct {
+	Total   int64
+	Active  int64
+	Created int64
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Counts 单次扫描聚合 总数/活跃数/since 之后新增数
+// 使用条件聚合而非多次 COUNT，避免重复扫表
+// ════════════════════════════════════════════════════════════════════════════
+
+func (r *UserRepository) Counts(ctx context.Context, since time.Time) (*UserCounts, error) {
+	db, release, err := database.Acquire(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var counts UserCounts
+	err = db.
+		Model(&model.User{}).
+		Select(
+			"COUNT(*) AS total, "+
+				"COALESCE(SUM(CASE WHEN active THEN 1 ELSE 0 END), 0) AS active, "+
+				"COALESCE(SUM(CASE WHEN created_at >= ? THEN 1 ELSE 0 END), 0) AS created",
+			since,
+		).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Loader 请求级按 id 批量加载用户，供关联数据 (如 orders → user) 解析使用
+// ════════════════════════════════════════════════════════════════════════════
+
+func (r *UserRepository) Loader(ctx context.Context) *loader.Loader[uuid.UUID, model.User] {
+	return loader.For(ctx, "user.id", loader.ByColumn(r.db, "id",
+		func(u model.User) uuid.UUID { return u.ID }))
+}
@@ -0,0 +1,43 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/model"
+	"github.com/liangze/go-project/internal/repository"
+	"github.com/liangze/go-project/internal/testutil"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// UpdateVersioned 版本不匹配返回 ErrVersionConflict，且不修改调用方的 updates
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestUpdateVersioned(t *testing.T) {
+	db := testutil.NewDB(t, &model.User{})
+	uid := uuid.New()
+	testutil.Seed(t, db, &model.User{ID: uid, Name: "Alice", Email: "a@example.com", Active: true})
+	repo := repository.NewUserRepository(db)
+	ctx := context.Background()
+
+	updates := map[string]any{"name": "Bob"}
+	if err := repo.UpdateVersioned(ctx, uid, 99, updates); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("过期版本 err = %v, want ErrVersionConflict", err)
+	}
+	if err := repo.UpdateVersioned(ctx, uid, 1, updates); err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("调用方 updates 被修改: %v", updates)
+	}
+
+	user, err := repo.FindByID(ctx, uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Name != "Bob" || user.Version != 2 {
+		t.Fatalf("user = %+v, want Bob v2", user)
+	}
+}
@@ -1,6 +1,6 @@
 /**
- * [INPUT]: 依赖 internal/handler, internal/middleware, internal/service, pkg/response, github.com/gin-gonic/gin
- * [OUTPUT]: 对外提供 RouterSetup, Setup()
+ * [INPUT]: 依赖 docs, internal/config, internal/dto, internal/handler, internal/middleware, internal/service, pkg/response, github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 RouterSetup, Setup(), BodyTypes
  * [POS]: router 模块的路由配置，被 cmd/api/main.go 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
@@ -9,6 +9,9 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/docs"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/dto"
 	"github.com/liangze/go-project/internal/handler"
 	"github.com/liangze/go-project/internal/middleware"
 	"github.com/liangze/go-project/internal/service"
@@ -23,19 +26,44 @@ type RouterSetup struct {
 	Engine *gin.Engine
 }
 
+// ════════════════════════════════════════════════════════════════════════════
+// BodyTypes 内置路由的请求体类型，BodyLogger 据此按 sensitive tag 脱敏
+// 新增带请求体的路由时在此登记，未登记的路由只按字段名单脱敏
+// ════════════════════════════════════════════════════════════════════════════
+
+var BodyTypes = middleware.BodyTypes{
+	"/api/v1/user/profile": dto.UpdateProfileReq{},
+}
+
 // ════════════════════════════════════════════════════════════════════════════
 // Setup 配置路由
+// types 传给 BodyLogger 的请求体类型 (通常为 BodyTypes)
+// extra 追加在内置中间件之后、业务路由之前 (如认证中间件)
 // ════════════════════════════════════════════════════════════════════════════
 
-func Setup(svc *service.ServiceGroup) *RouterSetup {
+func Setup(svc *service.ServiceGroup, types middleware.BodyTypes, extra ...gin.HandlerFunc) *RouterSetup {
 	r := gin.New()
+	// ClientIP 只采信可信代理转发的 X-Forwarded-For，否则客户端可伪造来源 IP 绕过匿名限流
+	// 名单已在配置加载时校验，此处出错只可能是绕过了 config.Load
+	if err := r.SetTrustedProxies(config.GlobalConfig.Server.TrustedProxies); err != nil {
+		panic(err)
+	}
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// Middleware Chain (Order matters!)
 	// ─────────────────────────────────────────────────────────────────────────
 	r.Use(gin.Recovery())
+	r.Use(middleware.RequestID(config.GlobalConfig.Server.RequestIDHeader))
 	r.Use(middleware.GlobalErrorHandler)
-	r.Use(middleware.CORS())
+	r.Use(middleware.URILimit(config.GlobalConfig.Server.MaxURILength))
+	r.Use(middleware.CORS(config.GlobalConfig.CORS))
+	r.Use(middleware.Loaders())
+
+	// 请求体日志默认关闭，开启后仅输出脱敏结果
+	if cfg := config.GlobalConfig.Log; cfg.Body {
+		r.Use(middleware.BodyLogger(cfg, types))
+	}
+	r.Use(extra...)
 
 	// ─────────────────────────────────────────────────────────────────────────
 	// 健康检查
@@ -52,10 +80,35 @@ func Setup(svc *service.ServiceGroup) *RouterSetup {
 	// API 路由组
 	// ─────────────────────────────────────────────────────────────────────────
 	api := r.Group("/api/v1")
+	if cfg := config.GlobalConfig.RateLimit; cfg.Enabled {
+		// 认证中间件需先于此注册，才能按用户/套餐分级
+		api.Use(middleware.RateLimit(cfg, middleware.DefaultTierResolver))
+	}
 	{
 		// 用户模块
+		// 只读路由挂 ETag，轮询客户端可凭 If-None-Match 拿到 304
+		// 需登录的只读路由先挂 Private，响应不进共享缓存
+		private, etag := middleware.Private(), middleware.ETag()
 		userHandler := handler.NewUserHandler(svc.UserService)
-		api.GET("/user/profile/detail", middleware.Wrap(userHandler.GetProfile))
+		api.GET("/user/profile/detail", private, etag, middleware.Wrap(userHandler.GetProfile))
+		api.PATCH("/user/profile", middleware.Wrap(userHandler.UpdateProfile))
+		api.GET("/user/stats", private, etag, middleware.Wrap(userHandler.GetStats))
+		api.GET("/user/list", private, etag, middleware.Wrap(userHandler.ListUsers))
+	}
+
+	// API 文档 (OpenAPI 3 + Swagger UI)，默认关闭，生产环境不暴露接口清单
+	if config.GlobalConfig.Docs.Enabled {
+		docsHandler := handler.NewDocsHandler(docs.OpenAPI, "/api/v1/docs/openapi.json", "/api/v1/docs/assets")
+		api.GET("/docs", middleware.Wrap(docsHandler.UI))
+		api.GET("/docs/openapi.json", middleware.Wrap(docsHandler.Spec))
+		api.GET("/docs/assets/*filepath", middleware.Wrap(docsHandler.Asset))
+	}
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// 前端静态资源 (单二进制部署，可选)
+	// ─────────────────────────────────────────────────────────────────────────
+	if cfg := config.GlobalConfig.Static; cfg.Enabled {
+		r.NoRoute(middleware.SPA(cfg))
 	}
 
 	return &RouterSetup{Engine: r}
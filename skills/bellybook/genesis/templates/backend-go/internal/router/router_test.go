@@ -0,0 +1,125 @@
+package router_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/model"
+	"github.com/liangze/go-project/internal/router"
+	"github.com/liangze/go-project/internal/service"
+	"github.com/liangze/go-project/internal/testutil"
+)
+
+func newEngine(t *testing.T, cfg config.Config) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	prev := config.GlobalConfig
+	config.GlobalConfig = &cfg
+	t.Cleanup(func() { config.GlobalConfig = prev })
+
+	db := testutil.NewDB(t, &model.User{})
+	return router.Setup(service.NewServiceGroup(db), router.BodyTypes).Engine
+}
+
+func get(r http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// API 文档：默认不挂载；开启后页面只引用本站嵌入的 Swagger UI 资源
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestDocsDisabledByDefault(t *testing.T) {
+	r := newEngine(t, config.Config{Environment: "test"})
+
+	for _, path := range []string{"/api/v1/docs", "/api/v1/docs/openapi.json", "/api/v1/docs/assets/swagger-ui.css"} {
+		if rec := get(r, path); rec.Code != http.StatusNotFound {
+			t.Errorf("%s: status = %d, want 404", path, rec.Code)
+		}
+	}
+}
+
+func TestDocsEnabledServesEmbeddedAssets(t *testing.T) {
+	r := newEngine(t, config.Config{Environment: "test", Docs: config.DocsConfig{Enabled: true}})
+
+	page := get(r, "/api/v1/docs")
+	if page.Code != http.StatusOK {
+		t.Fatalf("docs status = %d, want 200", page.Code)
+	}
+	if body := page.Body.String(); strings.Contains(body, "https://") {
+		t.Fatalf("页面仍引用外部资源: %s", body)
+	}
+
+	cases := []struct {
+		name     string
+		path     string
+		wantType string
+	}{
+		{"OpenAPI 文档", "/api/v1/docs/openapi.json", "application/json"},
+		{"样式", "/api/v1/docs/assets/swagger-ui.css", "text/css"},
+		{"脚本", "/api/v1/docs/assets/swagger-ui-bundle.js", "javascript"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := get(r, tc.path)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.Contains(ct, tc.wantType) {
+				t.Fatalf("Content-Type = %q, want %q", ct, tc.wantType)
+			}
+		})
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// 匿名限流按真实来源计数：未配置可信代理时伪造 X-Forwarded-For 共用一个桶
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	const peer = "192.0.2.1" // httptest.NewRequest 的 RemoteAddr
+
+	cases := []struct {
+		name        string
+		proxies     []string
+		wantAllowed int
+	}{
+		{"默认不信任代理：伪造 IP 共用一个桶", nil, 1},
+		{"来自可信代理：按转发的客户端 IP 分桶", []string{peer}, 20},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newEngine(t, config.Config{
+				Environment: "test",
+				Server:      config.ServerConfig{TrustedProxies: tc.proxies},
+				RateLimit: config.RateLimitConfig{
+					Enabled: true,
+					Tiers:   map[string]config.TierLimit{"anonymous": {RPS: 0.01, Burst: 1}},
+				},
+			})
+
+			allowed := 0
+			for i := range 20 {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/user/list", nil)
+				req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i+1))
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, req)
+				if rec.Code != http.StatusTooManyRequests {
+					allowed++
+				}
+			}
+			if allowed != tc.wantAllowed {
+				t.Fatalf("放行 %d 次, want %d", allowed, tc.wantAllowed)
+			}
+		})
+	}
+}
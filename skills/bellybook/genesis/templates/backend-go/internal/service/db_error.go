@@ -0,0 +1,29 @@
+/**
+ * [INPUT]: 依赖 internal/common, pkg/database
+ * [OUTPUT]: 无 - 包内 dbErr 工具
+ * [POS]: service 模块的数据库错误转换，被本包各 Service 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package service
+
+import (
+	"errors"
+	"math"
+
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/pkg/database"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// dbErr 将数据访问错误转换为 BizErr
+// 连接池耗尽 -> ErrServiceBusy (503 + Retry-After)，其余 -> ErrInternalProcess
+// ════════════════════════════════════════════════════════════════════════════
+
+func dbErr(err error) error {
+	if errors.Is(err, database.ErrPoolExhausted) {
+		retryAfter := int(math.Ceil(database.PoolWait().Seconds()))
+		return common.ErrWith(common.ErrServiceBusy, common.KVPair{common.KeyRetryAfter: retryAfter})
+	}
+	return common.Err(common.ErrInternalProcess)
+}
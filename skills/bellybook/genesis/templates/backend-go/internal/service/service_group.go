@@ -1,5 +1,5 @@
 /**
- * [INPUT]: 依赖本包内的各 Service
+ * [INPUT]: 依赖本包内的各 Service, internal/repository, gorm.io/gorm
  * [OUTPUT]: 对外提供 ServiceGroup, NewServiceGroup()
  * [POS]: service 模块的服务组，被 router 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
@@ -7,6 +7,11 @@
 
 package service
 
+import (
+	"github.com/liangze/go-project/internal/repository"
+	"gorm.io/gorm"
+)
+
 // ════════════════════════════════════════════════════════════════════════════
 // ServiceGroup 服务组 - 统一管理所有业务服务
 // 通过依赖注入传递给 Handler
@@ -18,8 +23,8 @@ type ServiceGroup struct {
 }
 
 // NewServiceGroup 初始化服务组
-func NewServiceGroup() *ServiceGroup {
-	userSvc := NewUserService()
+func NewServiceGroup(db *gorm.DB) *ServiceGroup {
+	userSvc := NewUserService(repository.NewUserRepository(db))
 
 	return &ServiceGroup{
 		UserService: userSvc,
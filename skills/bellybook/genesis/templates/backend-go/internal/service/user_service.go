@@ -1,6 +1,6 @@
 /**
- * [INPUT]: 依赖 internal/common, github.com/google/uuid
- * [OUTPUT]: 对外提供 UserService, NewUserService()
+ * [INPUT]: 依赖 internal/common, internal/dto, internal/model, internal/repository, pkg/cache, github.com/google/uuid, gorm.io/gorm
+ * [OUTPUT]: 对外提供 UserService, NewUserService(), UserProfile, UserSummary, UserStats, List()
  * [POS]: service 模块的用户服务，被 handler/user_handler.go 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
@@ -8,8 +8,23 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/dto"
+	"github.com/liangze/go-project/internal/model"
+	"github.com/liangze/go-project/internal/repository"
+	"github.com/liangze/go-project/pkg/cache"
+	"gorm.io/gorm"
+)
+
+// 统计结果缓存时长 & 新增用户统计窗口
+const (
+	userStatsTTL    = 30 * time.Second
+	userStatsWindow = 7 * 24 * time.Hour
 )
 
 // ════════════════════════════════════════════════════════════════════════════
@@ -17,11 +32,15 @@ import (
 // ════════════════════════════════════════════════════════════════════════════
 
 type UserService struct {
-	// 可注入 repository
+	repo  *repository.UserRepository
+	stats *cache.Value[*UserStats]
 }
 
-func NewUserService() *UserService {
-	return &UserService{}
+func NewUserService(repo *repository.UserRepository) *UserService {
+	return &UserService{
+		repo:  repo,
+		stats: cache.NewValue[*UserStats](userStatsTTL),
+	}
 }
 
 // ════════════════════════════════════════════════════════════════════════════
@@ -29,25 +48,127 @@ func NewUserService() *UserService {
 // ════════════════════════════════════════════════════════════════════════════
 
 type UserProfile struct {
-	ID    uuid.UUID `json:"id"`
-	Name  string    `json:"name"`
-	Email string    `json:"email"`
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email"`
+	Version int64     `json:"version"`
+}
+
+func toProfile(u *model.User) *UserProfile {
+	return &UserProfile{
+		ID:      u.ID,
+		Name:    u.Name,
+		Email:   u.Email,
+		Version: u.Version,
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UserSummary 列表项，仅含公开字段 (不含邮箱等联系方式)
+// ════════════════════════════════════════════════════════════════════════════
+
+type UserSummary struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UserStats 用户聚合统计
+// ════════════════════════════════════════════════════════════════════════════
+
+type UserStats struct {
+	Total        int64 `json:"total"`
+	Active       int64 `json:"active"`
+	CreatedLast7 int64 `json:"created_last_7_days"`
 }
 
 // ════════════════════════════════════════════════════════════════════════════
 // GetByID 根据ID获取用户信息
 // ════════════════════════════════════════════════════════════════════════════
 
-func (s *UserService) GetByID(userID uuid.UUID) (*UserProfile, error) {
-	// TODO: 实际从数据库查询
+func (s *UserService) GetByID(ctx context.Context, userID uuid.UUID) (*UserProfile, error) {
 	if userID == uuid.Nil {
 		return nil, common.Err(common.ErrUserNotFound)
 	}
 
-	// 模拟返回
-	return &UserProfile{
-		ID:    userID,
-		Name:  "Test User",
-		Email: "test@example.com",
-	}, nil
+	user, err := s.repo.FindByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, common.Err(common.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, dbErr(err)
+	}
+
+	return toProfile(user), nil
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// UpdateProfile 基于版本号的乐观锁更新
+// version 为调用方读取时的版本，已被他人修改则返回 ErrPreconditionFailed
+// ════════════════════════════════════════════════════════════════════════════
+
+func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, version int64, req *dto.UpdateProfileReq) (*UserProfile, error) {
+	updates := map[string]any{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if len(updates) == 0 {
+		return s.GetByID(ctx, userID)
+	}
+
+	err := s.repo.UpdateVersioned(ctx, userID, version, updates)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return nil, common.Err(common.ErrPreconditionFailed)
+	}
+	if err != nil {
+		return nil, dbErr(err)
+	}
+
+	return s.GetByID(ctx, userID)
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// List 分页列出用户 (按注册时间倒序)，带 cursor 走游标分页，否则按 page 偏移
+// ════════════════════════════════════════════════════════════════════════════
+
+func (s *UserService) List(ctx context.Context, req *dto.BasePageRequest) (*dto.PageResponse[UserSummary], error) {
+	req.Normalize()
+
+	cursor, err := dto.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, common.Err(common.ErrInvalidRequestData)
+	}
+
+	page, err := s.repo.List(ctx, cursor, req.GetOffset(), req.PageSize)
+	if errors.Is(err, dto.ErrInvalidCursor) {
+		return nil, common.Err(common.ErrInvalidRequestData)
+	}
+	if err != nil {
+		return nil, dbErr(err)
+	}
+
+	list := make([]UserSummary, 0, len(page.Users))
+	for _, u := range page.Users {
+		list = append(list, UserSummary{ID: u.ID, Name: u.Name})
+	}
+	return &dto.PageResponse[UserSummary]{List: list, Total: page.Total, NextCursor: page.Next}, nil
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Stats 获取用户聚合统计 (短时缓存，聚合查询代价较高)
+// ════════════════════════════════════════════════════════════════════════════
+
+func (s *UserService) Stats(ctx context.Context) (*UserStats, error) {
+	return s.stats.Get(ctx, func(ctx context.Context) (*UserStats, error) {
+		counts, err := s.repo.Counts(ctx, time.Now().Add(-userStatsWindow))
+		if err != nil {
+			return nil, dbErr(err)
+		}
+
+		return &UserStats{
+			Total:        counts.Total,
+			Active:       counts.Active,
+			CreatedLast7: counts.Created,
+		}, nil
+	})
 }
@@ -0,0 +1,54 @@
+/**
+ * [INPUT]: 依赖 internal/common, internal/config, internal/router, internal/service, github.com/gin-gonic/gin, gorm.io/gorm
+ * [OUTPUT]: 对外提供 NewEngine(), FakeAuth(), HeaderTestUser
+ * [POS]: testutil 的应用装配，复用 router.Setup 的完整中间件链
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/router"
+	"github.com/liangze/go-project/internal/service"
+	"gorm.io/gorm"
+)
+
+// HeaderTestUser FakeAuth 读取的用户 ID 请求头
+const HeaderTestUser = "X-Test-User-ID"
+
+// ════════════════════════════════════════════════════════════════════════════
+// NewEngine 以给定 db 装配服务组与路由，返回可直接 ServeHTTP 的 engine
+// 未加载配置时使用零值配置 (各中间件均有默认值)，测试结束后恢复原配置
+// ════════════════════════════════════════════════════════════════════════════
+
+func NewEngine(t testing.TB, db *gorm.DB) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	if prev := config.GlobalConfig; prev == nil {
+		config.GlobalConfig = &config.Config{Environment: "test"}
+		t.Cleanup(func() { config.GlobalConfig = prev })
+	}
+
+	svc := service.NewServiceGroup(db)
+	return router.Setup(svc, router.BodyTypes, FakeAuth()).Engine
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// FakeAuth 测试用认证：将 X-Test-User-ID 写入 Context，替代真实鉴权
+// ════════════════════════════════════════════════════════════════════════════
+
+func FakeAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if id, err := uuid.Parse(c.GetHeader(HeaderTestUser)); err == nil {
+			c.Set(common.CtxKeyUserID, id)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,135 @@
+/**
+ * [INPUT]: 依赖 internal/common, internal/dto, net/http/httptest, github.com/google/uuid
+ * [OUTPUT]: 对外提供 Client(), TestClient, Response
+ * [POS]: testutil 的进程内 HTTP 客户端，仅供 *_test.go 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+// Package testutil 黑盒测试工具：经完整中间件链调用已装配的 engine
+//
+// 用法 (GetProfile 端到端，完整示例见 internal/handler/user_handler_test.go):
+//
+//	db := testutil.NewDB(t, &model.User{})
+//	uid := uuid.New()
+//	testutil.Seed(t, db, &model.User{ID: uid, Name: "Alice", Email: "a@example.com", Active: true})
+//
+//	api := testutil.Client(testutil.NewEngine(t, db))
+//	resp := api.As(uid).Get(t, "/api/v1/user/profile/detail", dto.CodeSuccess)
+//
+//	var profile service.UserProfile
+//	resp.Bind(t, &profile)
+//	if profile.Name != "Alice" {
+//		t.Fatalf("name = %q", profile.Name)
+//	}
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/dto"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// TestClient 基于 httptest 的进程内客户端
+// ════════════════════════════════════════════════════════════════════════════
+
+type TestClient struct {
+	handler http.Handler
+	header  http.Header
+}
+
+func Client(engine http.Handler) *TestClient {
+	return &TestClient{handler: engine, header: http.Header{}}
+}
+
+// With 返回附加请求头的新客户端，原客户端不变
+func (c *TestClient) With(key, value string) *TestClient {
+	header := c.header.Clone()
+	header.Set(key, value)
+	return &TestClient{handler: c.handler, header: header}
+}
+
+// As 以指定用户身份发起请求 (配合 FakeAuth)
+func (c *TestClient) As(userID uuid.UUID) *TestClient {
+	return c.With(HeaderTestUser, userID.String())
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Get / Post / Patch 发送请求并断言响应体 code
+// ════════════════════════════════════════════════════════════════════════════
+
+func (c *TestClient) Get(t testing.TB, path string, want dto.ResponseCode) *Response {
+	t.Helper()
+	return c.Do(t, http.MethodGet, path, nil, want)
+}
+
+func (c *TestClient) Post(t testing.TB, path string, body any, want dto.ResponseCode) *Response {
+	t.Helper()
+	return c.Do(t, http.MethodPost, path, body, want)
+}
+
+func (c *TestClient) Patch(t testing.TB, path string, body any, want dto.ResponseCode) *Response {
+	t.Helper()
+	return c.Do(t, http.MethodPatch, path, body, want)
+}
+
+// Do 通用请求：body 非 nil 时按 JSON 编码
+func (c *TestClient) Do(t testing.TB, method, path string, body any, want dto.ResponseCode) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("编码请求体失败: %v", err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	resp := &Response{HTTP: rec}
+	if err := json.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+		t.Fatalf("%s %s: 响应不是 BaseResponse (status=%d): %s", method, path, rec.Code, rec.Body.String())
+	}
+	if resp.Code != want {
+		t.Fatalf("%s %s: code = %d, want %d (message=%q)", method, path, resp.Code, want, resp.Message)
+	}
+	return resp
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Response 解析后的统一响应，Data 延迟绑定到具体类型
+// ════════════════════════════════════════════════════════════════════════════
+
+type Response struct {
+	Code      dto.ResponseCode `json:"code"`
+	Message   string           `json:"message"`
+	Data      json.RawMessage  `json:"data"`
+	RequestID string           `json:"request_id"`
+
+	HTTP *httptest.ResponseRecorder `json:"-"`
+}
+
+// Bind 将 Data 解码到 out
+func (r *Response) Bind(t testing.TB, out any) {
+	t.Helper()
+	if err := json.Unmarshal(r.Data, out); err != nil {
+		t.Fatalf("解码 data 失败: %v (%s)", err, r.Data)
+	}
+}
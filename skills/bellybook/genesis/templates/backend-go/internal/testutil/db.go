@@ -0,0 +1,62 @@
+/**
+ * [INPUT]: 依赖 gorm.io/gorm, github.com/glebarez/sqlite (纯 Go 实现，无需 cgo)
+ * [OUTPUT]: 对外提供 NewDB(), Seed()
+ * [POS]: testutil 的内存数据库，供端到端测试播种数据
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// NewDB 每个测试独立的内存 SQLite，自动迁移 models，测试结束关闭
+// ════════════════════════════════════════════════════════════════════════════
+
+func NewDB(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+
+	// 命名 + 共享缓存：同一测试内多连接可见同一库，测试之间互相隔离
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", name)
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("打开内存数据库失败: %v", err)
+	}
+
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("迁移失败: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// Seed 批量写入测试数据
+// 带 default 标签的字段取零值时由 GORM 换成默认值 (如 Active=false 会写成 true)，
+// 需要零值时写入后显式更新该列:
+//
+//	db.Model(&model.User{}).Where("id = ?", id).Update("active", false)
+func Seed(t testing.TB, db *gorm.DB, rows ...any) {
+	t.Helper()
+	for _, row := range rows {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("写入种子数据失败: %v", err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+//go:build mage
+
+/**
+ * [INPUT]: 依赖 github.com/magefile/mage/sh, tools/openapi
+ * [OUTPUT]: 对外提供 mage 构建目标 Docs, Build
+ * [POS]: 项目构建脚本，mage <target> 调用
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package main
+
+import "github.com/magefile/mage/sh"
+
+// Docs 由 handler 的 swag 注释重新生成 docs/openapi.json
+// tools/openapi 为独立 module，生成器依赖不进入服务本身
+func Docs() error {
+	return sh.RunV("go", "-C", "tools/openapi", "run", ".",
+		"-root", "../..", "-out", "../../docs/openapi.json")
+}
+
+// Build 先同步文档再编译，保证内嵌文档与注释一致
+func Build() error {
+	if err := Docs(); err != nil {
+		return err
+	}
+	return sh.RunV("go", "build", "-o", "bin/api", "./cmd/api")
+}
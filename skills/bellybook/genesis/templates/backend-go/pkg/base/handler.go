@@ -1,6 +1,6 @@
 /**
  * [INPUT]: 依赖 internal/common, pkg/response, github.com/gin-gonic/gin, github.com/google/uuid
- * [OUTPUT]: 对外提供 MustAuth, MustBind, OK 等 Handler 工具函数
+ * [OUTPUT]: 对外提供 MustAuth, MustBind, MustBindQuery, OK 等 Handler 工具函数
  * [POS]: pkg/base 的核心工具，被所有 handler 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
@@ -19,7 +19,7 @@ import (
 // ════════════════════════════════════════════════════════════════════════════
 
 func MustAuth(c *gin.Context) (uuid.UUID, error) {
-	userID, exists := c.Get("user_id")
+	userID, exists := c.Get(common.CtxKeyUserID)
 	if !exists {
 		return uuid.UUID{}, common.Err(common.ErrUnauthorized)
 	}
@@ -37,6 +37,17 @@ func MustBind(c *gin.Context, req interface{}) error {
 	return nil
 }
 
+// ════════════════════════════════════════════════════════════════════════════
+// MustBindQuery 绑定并验证 Query 参数
+// ════════════════════════════════════════════════════════════════════════════
+
+func MustBindQuery(c *gin.Context, req interface{}) error {
+	if err := c.ShouldBindQuery(req); err != nil {
+		return common.Err(common.ErrInvalidRequestData)
+	}
+	return nil
+}
+
 // ════════════════════════════════════════════════════════════════════════════
 // OK 成功响应并返回 nil error
 // ════════════════════════════════════════════════════════════════════════════
@@ -0,0 +1,57 @@
+/**
+ * [INPUT]: 依赖 internal/common, github.com/gin-gonic/gin, github.com/google/uuid
+ * [OUTPUT]: 对外提供 VersionETag, SetETag, CheckIfMatch 条件写入工具
+ * [POS]: pkg/base 的 If-Match 乐观并发工具，被 handler 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package base
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/liangze/go-project/internal/common"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// VersionETag 由实体 ID + 版本号生成强 ETag，如 "<uuid>-v3"
+// 带上 ID：不同实体的同一版本号不会互相命中 If-None-Match / If-Match
+// ════════════════════════════════════════════════════════════════════════════
+
+func VersionETag(id uuid.UUID, version int64) string {
+	return `"` + id.String() + `-v` + strconv.FormatInt(version, 10) + `"`
+}
+
+// SetETag 写出 ETag 响应头
+func SetETag(c *gin.Context, etag string) {
+	c.Header("ETag", etag)
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// CheckIfMatch 校验 If-Match 与当前实体 ETag，不匹配返回 ErrPreconditionFailed (412)
+// 未携带 If-Match 时放行；"*" 匹配任意现存实体；弱 ETag 不参与比较 (RFC 9110)
+// 用法:
+//
+//	current, err := h.svc.GetByID(ctx, id)
+//	if err := base.CheckIfMatch(c, base.VersionETag(current.ID, current.Version)); err != nil {
+//		return err
+//	}
+// ════════════════════════════════════════════════════════════════════════════
+
+func CheckIfMatch(c *gin.Context, current string) error {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return nil
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || (tag == current && !strings.HasPrefix(tag, "W/")) {
+			return nil
+		}
+	}
+	return common.Err(common.ErrPreconditionFailed)
+}
@@ -0,0 +1,93 @@
+/**
+ * [INPUT]: 无外部依赖
+ * [OUTPUT]: 对外提供 Value[T], NewValue()
+ * [POS]: pkg/cache 的进程内短时缓存，被 service 层消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// Value 单值 TTL 缓存
+// 过期后由下一次 Get 重新加载，并发请求共享同一次加载
+// 加载在锁外、脱离调用方取消的 context 中进行：发起加载的请求断开
+// 不会中断共享加载，等待中的请求各自按自己的 ctx 放弃等待
+// ════════════════════════════════════════════════════════════════════════════
+
+type Value[T any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	value     T
+	expiresAt time.Time
+	inflight  *call[T]
+	gen       uint64 // Invalidate 递增，丢弃失效前发起的加载结果
+}
+
+// call 一次进行中的加载
+type call[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func NewValue[T any](ttl time.Duration) *Value[T] {
+	return &Value[T]{ttl: ttl}
+}
+
+// Get 命中则直接返回，否则加入 (或发起) 一次共享加载 (load 失败不缓存)
+func (v *Value[T]) Get(ctx context.Context, load func(context.Context) (T, error)) (T, error) {
+	v.mu.Lock()
+	if time.Now().Before(v.expiresAt) {
+		value := v.value
+		v.mu.Unlock()
+		return value, nil
+	}
+
+	c := v.inflight
+	if c == nil {
+		c = &call[T]{done: make(chan struct{})}
+		v.inflight = c
+		go v.run(context.WithoutCancel(ctx), c, v.gen, load)
+	}
+	v.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// run 执行加载并写回缓存，完成后唤醒全部等待者
+func (v *Value[T]) run(ctx context.Context, c *call[T], gen uint64, load func(context.Context) (T, error)) {
+	c.value, c.err = load(ctx)
+
+	v.mu.Lock()
+	if c.err == nil && gen == v.gen {
+		v.value = c.value
+		v.expiresAt = time.Now().Add(v.ttl)
+	}
+	if v.inflight == c {
+		v.inflight = nil
+	}
+	v.mu.Unlock()
+
+	close(c.done)
+}
+
+// Invalidate 使缓存立即失效，进行中的加载结果不再写回
+func (v *Value[T]) Invalidate() {
+	v.mu.Lock()
+	v.expiresAt = time.Time{}
+	v.inflight = nil
+	v.gen++
+	v.mu.Unlock()
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 发起加载的请求取消后，共享加载继续完成，其余等待者拿到结果且只加载一次
+func TestValueSharedLoadSurvivesCallerCancel(t *testing.T) {
+	v := NewValue[int](time.Minute)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (int, error) {
+		loads.Add(1)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return 42, nil
+	}
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := v.Get(firstCtx, load)
+		firstErr <- err
+	}()
+	waitFor(t, func() bool { return loads.Load() == 1 })
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = v.Get(context.Background(), load)
+		}()
+	}
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("首个调用方 err = %v, want context.Canceled", err)
+	}
+	close(release)
+	wg.Wait()
+
+	for i, got := range results {
+		if got != 42 {
+			t.Fatalf("results[%d] = %d, want 42", i, got)
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("load 调用 %d 次, want 1", n)
+	}
+
+	// 结果已缓存，不再加载
+	if got, err := v.Get(context.Background(), load); err != nil || got != 42 || loads.Load() != 1 {
+		t.Fatalf("缓存命中失败: got=%d err=%v loads=%d", got, err, loads.Load())
+	}
+}
+
+func TestValueLoadErrorNotCached(t *testing.T) {
+	v := NewValue[int](time.Minute)
+
+	boom := errors.New("boom")
+	if _, err := v.Get(context.Background(), func(context.Context) (int, error) { return 0, boom }); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want boom", err)
+	}
+	got, err := v.Get(context.Background(), func(context.Context) (int, error) { return 7, nil })
+	if err != nil || got != 7 {
+		t.Fatalf("got=%d err=%v, want 7", got, err)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("等待超时")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -1,6 +1,6 @@
 /**
  * [INPUT]: 依赖 gorm.io/gorm, gorm.io/driver/postgres, internal/config
- * [OUTPUT]: 对外提供 DB, Init(), Close()
+ * [OUTPUT]: 对外提供 DB, Init(), Migrate(), Close()；连接池限时获取见 pool.go
  * [POS]: pkg/database 的数据库连接模块，被 cmd/api/main.go 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
@@ -52,9 +52,22 @@ func Init() error {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
+	if cfg.PoolWaitTimeout > 0 {
+		poolWait = cfg.PoolWaitTimeout
+	}
+
 	return nil
 }
 
+// ════════════════════════════════════════════════════════════════════════════
+// Migrate 按模型定义建表/补列/补索引 (GORM AutoMigrate，不删除列)
+// 需要删列、改类型或数据迁移时改用独立迁移脚本
+// ════════════════════════════════════════════════════════════════════════════
+
+func Migrate(models ...any) error {
+	return DB.AutoMigrate(models...)
+}
+
 // ════════════════════════════════════════════════════════════════════════════
 // Close 关闭数据库连接
 // ════════════════════════════════════════════════════════════════════════════
@@ -0,0 +1,49 @@
+/**
+ * [INPUT]: 依赖 internal/dto, gorm.io/gorm
+ * [OUTPUT]: 对外提供 KeysetAfter, Keyset(), NextPage()
+ * [POS]: pkg/database 的游标 (keyset) 分页工具，被 repository 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package database
+
+import (
+	"github.com/liangze/go-project/internal/dto"
+	"gorm.io/gorm"
+)
+
+// KeysetAfter 上一页最后一条的 (排序键, 主键)，由 repository 按列类型从游标解析
+type KeysetAfter struct {
+	Sort any
+	ID   any
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Keyset 按 (column, id) 倒序的游标分页 scope
+// after 为 nil 时从头开始 (可再叠加 Offset 做偏移分页)；多取一条用于判断是否存在下一页 (配合 NextPage)
+// 需要 (column, id) 联合索引才能避免排序扫描
+// 用法:
+//
+//	db.Scopes(database.Keyset("created_at", after, limit)).Find(&rows)
+// ════════════════════════════════════════════════════════════════════════════
+
+func Keyset(column string, after *KeysetAfter, limit int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if after != nil {
+			db = db.Where("("+column+", id) < (?, ?)", after.Sort, after.ID)
+		}
+		return db.Order(column + " DESC").Order("id DESC").Limit(limit + 1)
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// NextPage 裁掉多取的一条并生成下一页游标；没有下一页时游标为空
+// ════════════════════════════════════════════════════════════════════════════
+
+func NextPage[T any](rows []T, limit int, cursorOf func(T) dto.PageCursor) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+	rows = rows[:limit]
+	return rows, dto.EncodeCursor(cursorOf(rows[len(rows)-1]))
+}
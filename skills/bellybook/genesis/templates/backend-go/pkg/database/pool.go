@@ -0,0 +1,72 @@
+/**
+ * [INPUT]: 依赖 gorm.io/gorm, database/sql
+ * [OUTPUT]: 对外提供 Acquire(), ErrPoolExhausted
+ * [POS]: pkg/database 的连接池限时获取，被 repository 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 连接池耗尽：连接数已达上限，且在等待阈值内未等到空闲连接
+var ErrPoolExhausted = errors.New("database: connection pool exhausted")
+
+const defaultPoolWait = 500 * time.Millisecond
+
+// 等待阈值，由 Init 按配置覆盖
+var poolWait = defaultPoolWait
+
+// PoolWait 当前的连接等待阈值
+func PoolWait() time.Duration {
+	return poolWait
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Acquire 在阈值内获取一条独占连接，返回绑定该连接的会话
+// 超时且连接数已达上限时返回 ErrPoolExhausted，调用方应快速失败而非继续排队
+// 未达上限的超时 (新建连接过慢) 原样返回，不伪装成池耗尽
+// 用法:
+//
+//	tx, release, err := database.Acquire(ctx, r.db)
+//	if err != nil {
+//		return err
+//	}
+//	defer release()
+// ════════════════════════════════════════════════════════════════════════════
+
+func Acquire(ctx context.Context, db *gorm.DB) (*gorm.DB, func(), error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, poolWait)
+	defer cancel()
+
+	conn, err := sqlDB.Conn(waitCtx)
+	if err != nil {
+		// 区分池满排队超时、建连超时与调用方自身取消
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) && saturated(sqlDB) {
+			return nil, nil, ErrPoolExhausted
+		}
+		return nil, nil, err
+	}
+
+	tx := db.Session(&gorm.Session{NewDB: true, Context: ctx})
+	tx.Statement.ConnPool = conn
+	return tx, func() { _ = conn.Close() }, nil
+}
+
+// saturated 连接数已达 MaxOpenConns (0 表示不限，永不饱和)
+func saturated(sqlDB *sql.DB) bool {
+	stats := sqlDB.Stats()
+	return stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// slowConnector 包装 sqlite 驱动，stall 置位后新建连接阻塞到 ctx 结束 (模拟建连超时)
+type slowConnector struct {
+	drv   driver.Driver
+	stall atomic.Bool
+}
+
+func (c *slowConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.stall.Load() {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return c.drv.Open(":memory:")
+}
+
+func (c *slowConnector) Driver() driver.Driver { return c.drv }
+
+func newPoolDB(t *testing.T, maxOpen int) (*gorm.DB, *slowConnector) {
+	t.Helper()
+
+	probe, err := sql.Open(sqlite.DriverName, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	connector := &slowConnector{drv: probe.Driver()}
+	_ = probe.Close()
+
+	sqlDB := sql.OpenDB(connector)
+	sqlDB.SetMaxOpenConns(maxOpen)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(&sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, connector
+}
+
+func withPoolWait(t *testing.T, d time.Duration) {
+	t.Helper()
+	prev := poolWait
+	poolWait = d
+	t.Cleanup(func() { poolWait = prev })
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// 只有连接数已达上限的等待超时才映射为 ErrPoolExhausted
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestAcquireTimeouts(t *testing.T) {
+	withPoolWait(t, 20*time.Millisecond)
+
+	cases := []struct {
+		name    string
+		maxOpen int
+		stall   bool
+		want    error
+	}{
+		{"连接已满：池耗尽", 1, false, ErrPoolExhausted},
+		{"未满但建连超时：原样返回", 2, true, context.DeadlineExceeded},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, connector := newPoolDB(t, tc.maxOpen)
+
+			_, release, err := Acquire(context.Background(), db)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer release()
+			connector.stall.Store(tc.stall)
+
+			_, _, err = Acquire(context.Background(), db)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("err = %v, want %v", err, tc.want)
+			}
+			if tc.want != ErrPoolExhausted && errors.Is(err, ErrPoolExhausted) {
+				t.Fatalf("建连超时被误判为池耗尽: %v", err)
+			}
+		})
+	}
+}
+
+func TestAcquireCallerCanceled(t *testing.T) {
+	withPoolWait(t, time.Second)
+	db, _ := newPoolDB(t, 1)
+
+	_, release, err := Acquire(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := Acquire(ctx, db); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,76 @@
+/**
+ * [INPUT]: 依赖 gorm.io/gorm, pkg/database, loader.go
+ * [OUTPUT]: 对外提供 ByColumn(), GroupByColumn()
+ * [POS]: pkg/loader 的 GORM 批量查询构造器，被 repository 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package loader
+
+import (
+	"context"
+
+	"github.com/liangze/go-project/pkg/database"
+	"gorm.io/gorm"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// ByColumn 一对一：WHERE column IN (keys)，如按 id 加载 users
+// 连接经 database.Acquire 获取，池耗尽时返回 ErrPoolExhausted
+// ════════════════════════════════════════════════════════════════════════════
+
+func ByColumn[K comparable, V any](db *gorm.DB, column string, keyOf func(V) K) FetchFunc[K, V] {
+	return func(ctx context.Context, keys []K) (map[K]V, error) {
+		rows, err := findIn[K, V](ctx, db, column, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make(map[K]V, len(rows))
+		for _, row := range rows {
+			out[keyOf(row)] = row
+		}
+		return out, nil
+	}
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// GroupByColumn 一对多：WHERE fk IN (keys) 后按外键分组，如 users → orders
+// ════════════════════════════════════════════════════════════════════════════
+
+func GroupByColumn[K comparable, V any](db *gorm.DB, column string, keyOf func(V) K) FetchFunc[K, []V] {
+	return func(ctx context.Context, keys []K) (map[K][]V, error) {
+		rows, err := findIn[K, V](ctx, db, column, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make(map[K][]V, len(keys))
+		for _, row := range rows {
+			k := keyOf(row)
+			out[k] = append(out[k], row)
+		}
+		// 无关联记录的 key 返回空切片，而非"不存在"
+		for _, k := range keys {
+			if _, ok := out[k]; !ok {
+				out[k] = []V{}
+			}
+		}
+		return out, nil
+	}
+}
+
+// findIn 限时获取连接后执行单次 IN 查询
+func findIn[K comparable, V any](ctx context.Context, db *gorm.DB, column string, keys []K) ([]V, error) {
+	tx, release, err := database.Acquire(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var rows []V
+	if err := tx.Where(column+" IN ?", keys).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
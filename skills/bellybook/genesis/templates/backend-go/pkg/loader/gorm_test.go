@@ -0,0 +1,86 @@
+package loader_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/liangze/go-project/internal/testutil"
+	"github.com/liangze/go-project/pkg/loader"
+	"gorm.io/gorm"
+)
+
+type author struct {
+	ID   int
+	Name string
+}
+
+type book struct {
+	ID       int
+	AuthorID int
+}
+
+// countQueries 统计 db 上执行的 SELECT 次数
+func countQueries(t *testing.T, db *gorm.DB) *atomic.Int32 {
+	t.Helper()
+	var n atomic.Int32
+	err := db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		n.Add(1)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &n
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// N 个 id 只发一次 IN 查询，随后的 Load 命中请求内缓存
+// ════════════════════════════════════════════════════════════════════════════
+
+func TestByColumnSingleQueryForNIDs(t *testing.T) {
+	db := testutil.NewDB(t, &author{})
+	ids := []int{1, 2, 3, 4, 5}
+	for _, id := range ids {
+		testutil.Seed(t, db, &author{ID: id, Name: "a"})
+	}
+	queries := countQueries(t, db)
+
+	ctx := loader.WithRegistry(context.Background())
+	byID := func() *loader.Loader[int, author] {
+		return loader.For(ctx, "author.id", loader.ByColumn(db, "id", func(a author) int { return a.ID }))
+	}
+
+	byID().Prime(append(ids, 404)...)
+	for _, id := range ids {
+		a, ok, err := byID().Load(ctx, id)
+		if err != nil || !ok || a.ID != id {
+			t.Fatalf("Load(%d) = %+v, %v, %v", id, a, ok, err)
+		}
+	}
+	if _, ok, err := byID().Load(ctx, 404); err != nil || ok {
+		t.Fatalf("Load(404) ok = %v, err = %v, want not found", ok, err)
+	}
+
+	if n := queries.Load(); n != 1 {
+		t.Fatalf("查询次数 = %d, want 1", n)
+	}
+}
+
+func TestGroupByColumnSingleQueryForNIDs(t *testing.T) {
+	db := testutil.NewDB(t, &book{})
+	testutil.Seed(t, db, &book{ID: 1, AuthorID: 1}, &book{ID: 2, AuthorID: 1}, &book{ID: 3, AuthorID: 2})
+	queries := countQueries(t, db)
+
+	l := loader.New(loader.GroupByColumn(db, "author_id", func(b book) int { return b.AuthorID }))
+	got, err := l.LoadMany(context.Background(), []int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got[1]) != 2 || len(got[2]) != 1 || got[3] == nil || len(got[3]) != 0 {
+		t.Fatalf("分组结果 = %+v", got)
+	}
+	if n := queries.Load(); n != 1 {
+		t.Fatalf("查询次数 = %d, want 1", n)
+	}
+}
@@ -0,0 +1,134 @@
+/**
+ * [INPUT]: 依赖 context, sync
+ * [OUTPUT]: 对外提供 Loader[K,V], FetchFunc, New(), For(), WithRegistry()
+ * [POS]: pkg/loader 的请求级批量加载器 (dataloader 模式)，被 repository, middleware 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package loader
+
+import (
+	"context"
+	"sync"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// FetchFunc 一次性加载一批 key，返回结果中缺失的 key 视为不存在
+// ════════════════════════════════════════════════════════════════════════════
+
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// ════════════════════════════════════════════════════════════════════════════
+// Loader 收集 key → 单次批量查询 → 请求内缓存
+// 用法:
+//
+//	l.Prime(ids...)            // 收集阶段，不发查询
+//	user, ok, err := l.Load(ctx, id) // 首次 Load 一次性查询全部已收集的 key
+// ════════════════════════════════════════════════════════════════════════════
+
+type Loader[K comparable, V any] struct {
+	mu      sync.Mutex
+	fetch   FetchFunc[K, V]
+	cache   map[K]V
+	missing map[K]struct{} // 已查询但不存在，避免重复查询
+	pending map[K]struct{}
+}
+
+func New[K comparable, V any](fetch FetchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		cache:   make(map[K]V),
+		missing: make(map[K]struct{}),
+		pending: make(map[K]struct{}),
+	}
+}
+
+// Prime 登记待加载的 key，下一次 Load/LoadMany 时合并为一次查询
+func (l *Loader[K, V]) Prime(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, k := range keys {
+		if !l.known(k) {
+			l.pending[k] = struct{}{}
+		}
+	}
+}
+
+// Load 加载单个 key；ok=false 表示记录不存在
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.known(key) {
+		l.pending[key] = struct{}{}
+		if err := l.flush(ctx); err != nil {
+			var zero V
+			return zero, false, err
+		}
+	}
+
+	v, ok := l.cache[key]
+	return v, ok, nil
+}
+
+// LoadMany 加载一批 key，结果只包含存在的记录
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) (map[K]V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, k := range keys {
+		if !l.known(k) {
+			l.pending[k] = struct{}{}
+		}
+	}
+	if err := l.flush(ctx); err != nil {
+		return nil, err
+	}
+
+	out := make(map[K]V, len(keys))
+	for _, k := range keys {
+		if v, ok := l.cache[k]; ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// flush 对全部待加载 key 发起一次查询 (调用方持有锁)
+// ────────────────────────────────────────────────────────────────────────────
+
+func (l *Loader[K, V]) flush(ctx context.Context) error {
+	if len(l.pending) == 0 {
+		return nil
+	}
+
+	keys := make([]K, 0, len(l.pending))
+	for k := range l.pending {
+		keys = append(keys, k)
+	}
+
+	found, err := l.fetch(ctx, keys)
+	if err != nil {
+		return err // pending 保留，允许重试
+	}
+
+	for _, k := range keys {
+		if v, ok := found[k]; ok {
+			l.cache[k] = v
+		} else {
+			l.missing[k] = struct{}{}
+		}
+	}
+	l.pending = make(map[K]struct{})
+	return nil
+}
+
+func (l *Loader[K, V]) known(k K) bool {
+	if _, ok := l.cache[k]; ok {
+		return true
+	}
+	_, ok := l.missing[k]
+	return ok
+}
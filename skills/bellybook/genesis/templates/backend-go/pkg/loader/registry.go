@@ -0,0 +1,51 @@
+/**
+ * [INPUT]: 依赖 context, sync, loader.go
+ * [OUTPUT]: 对外提供 Registry, WithRegistry(), For()
+ * [POS]: pkg/loader 的请求级 Loader 注册表，随 request context 传递
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package loader
+
+import (
+	"context"
+	"sync"
+)
+
+type registryKey struct{}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Registry 单个请求内的 Loader 集合，按名称 (关联名) 区分
+// ════════════════════════════════════════════════════════════════════════════
+
+type Registry struct {
+	mu      sync.Mutex
+	loaders map[string]any
+}
+
+// WithRegistry 为请求 context 挂载新的注册表
+func WithRegistry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, registryKey{}, &Registry{loaders: make(map[string]any)})
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// For 获取请求内名为 name 的 Loader，不存在则用 fetch 创建
+// context 未挂载注册表时返回一次性 Loader (不跨调用缓存)
+// ════════════════════════════════════════════════════════════════════════════
+
+func For[K comparable, V any](ctx context.Context, name string, fetch FetchFunc[K, V]) *Loader[K, V] {
+	reg, ok := ctx.Value(registryKey{}).(*Registry)
+	if !ok {
+		return New(fetch)
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if l, ok := reg.loaders[name].(*Loader[K, V]); ok {
+		return l
+	}
+	l := New(fetch)
+	reg.loaders[name] = l
+	return l
+}
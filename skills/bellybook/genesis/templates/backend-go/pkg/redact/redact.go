@@ -0,0 +1,164 @@
+/**
+ * [INPUT]: 依赖 encoding/json, reflect
+ * [OUTPUT]: 对外提供 Redactor, New(), Mask 常量
+ * [POS]: pkg/redact 的请求体脱敏工具，被 middleware/body_logger.go 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package redact
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// 常量 & 默认敏感字段
+// ════════════════════════════════════════════════════════════════════════════
+
+const (
+	Mask       = "******"
+	NonJSONOut = "[non-json body omitted]"
+	tagName    = "sensitive"
+)
+
+// 始终脱敏的字段名，配置名单在此基础上追加
+var defaultFields = []string{"password", "token", "access_token", "refresh_token", "secret"}
+
+// ════════════════════════════════════════════════════════════════════════════
+// Redactor 请求体脱敏器
+// 已知类型：按 `sensitive:"true"` tag 脱敏
+// 未知类型 / 兜底：按字段名单脱敏 (大小写不敏感，与 encoding/json 绑定规则一致)
+// ════════════════════════════════════════════════════════════════════════════
+
+type Redactor struct {
+	names map[string]struct{}
+}
+
+func New(names []string) *Redactor {
+	r := &Redactor{names: make(map[string]struct{})}
+	for _, n := range append(defaultFields, names...) {
+		r.names[strings.ToLower(n)] = struct{}{}
+	}
+	return r
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// JSON 返回脱敏后的请求体；typ 为 nil 表示类型未知
+// 非 JSON 内容一律不输出原文
+// ════════════════════════════════════════════════════════════════════════════
+
+func (r *Redactor) JSON(body []byte, typ reflect.Type) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return NonJSONOut
+	}
+
+	if typ != nil {
+		v = r.byTag(v, typ)
+	}
+	v = r.byName(v)
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return NonJSONOut
+	}
+	return string(out)
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// byTag 沿结构体定义递归，屏蔽带 sensitive tag 的字段
+// ────────────────────────────────────────────────────────────────────────────
+
+func (r *Redactor) byTag(v any, typ reflect.Type) any {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			// 匿名嵌入结构体的字段会被 encoding/json 提升到同一层
+			if field.Anonymous && field.Tag.Get("json") == "" {
+				r.byTag(obj, field.Type)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			name := jsonName(field)
+			if name == "-" {
+				continue
+			}
+			for key, val := range obj {
+				if !strings.EqualFold(key, name) {
+					continue
+				}
+				if field.Tag.Get(tagName) == "true" {
+					obj[key] = Mask
+				} else {
+					obj[key] = r.byTag(val, field.Type)
+				}
+			}
+		}
+		return obj
+
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]any)
+		if !ok {
+			return v
+		}
+		for i := range arr {
+			arr[i] = r.byTag(arr[i], typ.Elem())
+		}
+		return arr
+
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		for key, val := range obj {
+			obj[key] = r.byTag(val, typ.Elem())
+		}
+		return obj
+	}
+	return v
+}
+
+// ────────────────────────────────────────────────────────────────────────────
+// byName 按字段名单递归屏蔽
+// ────────────────────────────────────────────────────────────────────────────
+
+func (r *Redactor) byName(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for key, val := range t {
+			if _, hit := r.names[strings.ToLower(key)]; hit {
+				t[key] = Mask
+				continue
+			}
+			t[key] = r.byName(val)
+		}
+	case []any:
+		for i := range t {
+			t[i] = r.byName(t[i])
+		}
+	}
+	return v
+}
+
+// jsonName 取字段的 JSON 键名
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return field.Name
+}
@@ -0,0 +1,68 @@
+package redact
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type card struct {
+	Number string `json:"number" sensitive:"true"`
+	Brand  string `json:"brand"`
+}
+
+type payReq struct {
+	OTP    string `json:"otp" sensitive:"true"`
+	Amount int    `json:"amount"`
+	Cards  []card `json:"cards"`
+}
+
+func TestRedactorJSON(t *testing.T) {
+	r := New([]string{"ID_Card"})
+
+	cases := []struct {
+		name string
+		body string
+		typ  reflect.Type
+		want string
+	}{
+		{
+			"sensitive tag 字段 (含嵌套切片)",
+			`{"otp":"123456","amount":100,"cards":[{"number":"4111","brand":"visa"}]}`,
+			reflect.TypeOf(payReq{}),
+			`{"amount":100,"cards":[{"brand":"visa","number":"******"}],"otp":"******"}`,
+		},
+		{
+			"tag 匹配大小写不敏感",
+			`{"OTP":"123456","amount":1}`,
+			reflect.TypeOf(&payReq{}),
+			`{"OTP":"******","amount":1}`,
+		},
+		{
+			"类型未知按名单兜底 (默认 + 配置，递归)",
+			`{"Password":"p","profile":{"id_card":"x","name":"n"},"items":[{"token":"t"}]}`,
+			nil,
+			`{"Password":"******","items":[{"token":"******"}],"profile":{"id_card":"******","name":"n"}}`,
+		},
+		{
+			"已知类型同样叠加名单",
+			`{"otp":"1","amount":1,"secret":"s"}`,
+			reflect.TypeOf(payReq{}),
+			`{"amount":1,"otp":"******","secret":"******"}`,
+		},
+		{"非 JSON 不输出原文", `password=hunter2`, nil, NonJSONOut},
+		{"截断的 JSON 不输出原文", `{"password":"hun`, reflect.TypeOf(payReq{}), NonJSONOut},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := r.JSON([]byte(tc.body), tc.typ)
+			if got != tc.want {
+				t.Fatalf("got  %s\nwant %s", got, tc.want)
+			}
+			if tc.want != NonJSONOut && !json.Valid([]byte(got)) {
+				t.Fatalf("输出不是合法 JSON: %s", got)
+			}
+		})
+	}
+}
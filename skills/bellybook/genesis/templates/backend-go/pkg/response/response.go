@@ -1,6 +1,6 @@
 /**
- * [INPUT]: 依赖 internal/dto, github.com/gin-gonic/gin
- * [OUTPUT]: 对外提供 Success, Custom 响应函数
+ * [INPUT]: 依赖 internal/common, internal/dto, github.com/gin-gonic/gin
+ * [OUTPUT]: 对外提供 Success, Custom, CustomStatus 响应函数
  * [POS]: pkg/response 的统一响应模块，被 handler, middleware 消费
  * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
  */
@@ -9,6 +9,7 @@ package response
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/liangze/go-project/internal/common"
 	"github.com/liangze/go-project/internal/dto"
 )
 
@@ -18,6 +19,7 @@ import (
 
 func Success(c *gin.Context, data interface{}) {
 	resp := dto.SuccessResponseWithMsg(data, "操作成功")
+	resp.RequestID = c.GetString(common.CtxKeyRequestID)
 	c.JSON(200, resp)
 }
 
@@ -26,6 +28,15 @@ func Success(c *gin.Context, data interface{}) {
 // ════════════════════════════════════════════════════════════════════════════
 
 func Custom(c *gin.Context, data interface{}, message string, code int) {
+	CustomStatus(c, 200, data, message, code)
+}
+
+// ════════════════════════════════════════════════════════════════════════════
+// CustomStatus 指定 HTTP 状态码的自定义响应
+// ════════════════════════════════════════════════════════════════════════════
+
+func CustomStatus(c *gin.Context, status int, data interface{}, message string, code int) {
 	resp := dto.Custom(data, message, code)
-	c.JSON(200, resp)
+	resp.RequestID = c.GetString(common.CtxKeyRequestID)
+	c.JSON(status, resp)
 }
@@ -0,0 +1,58 @@
+/**
+ * [INPUT]: 依赖 internal/common, internal/config, github.com/gin-gonic/gin, github.com/gorilla/websocket
+ * [OUTPUT]: 对外提供 Upgrader, NewUpgrader()
+ * [POS]: pkg/ws 的 WebSocket 升级工具，被 handler 消费
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package ws
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/liangze/go-project/internal/common"
+	"github.com/liangze/go-project/internal/config"
+)
+
+// ════════════════════════════════════════════════════════════════════════════
+// Upgrader WebSocket 升级器，来源校验复用 HTTP CORS 白名单
+// 浏览器握手会自动携带 Cookie，因此只放行显式列出的来源，空白名单或 "*" 一律拒绝
+// 用法:
+//
+//	conn, err := h.ws.Upgrade(c)
+//	if err != nil {
+//		return err // 非法来源 -> 403
+//	}
+// ════════════════════════════════════════════════════════════════════════════
+
+type Upgrader struct {
+	cors     config.CORSConfig
+	upgrader websocket.Upgrader
+}
+
+func NewUpgrader(cors config.CORSConfig) *Upgrader {
+	u := &Upgrader{cors: cors}
+	u.upgrader = websocket.Upgrader{CheckOrigin: u.checkOrigin}
+	return u
+}
+
+// Upgrade 校验来源后升级连接
+// 来源不在白名单时返回 ErrForbiddenOrigin，由错误处理器输出 403
+func (u *Upgrader) Upgrade(c *gin.Context) (*websocket.Conn, error) {
+	if !u.checkOrigin(c.Request) {
+		return nil, common.Err(common.ErrForbiddenOrigin)
+	}
+	// 握手失败时 gorilla 已写出 400 响应
+	return u.upgrader.Upgrade(c.Writer, c.Request, nil)
+}
+
+// checkOrigin 无 Origin 头视为非浏览器客户端，直接放行
+func (u *Upgrader) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	return u.cors.ListsOrigin(origin)
+}
@@ -0,0 +1,78 @@
+package ws_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/liangze/go-project/internal/config"
+	"github.com/liangze/go-project/internal/middleware"
+	"github.com/liangze/go-project/pkg/ws"
+)
+
+const (
+	allowedOrigin = "https://app.example.com"
+	evilOrigin    = "https://evil.example.net"
+)
+
+// newServer 挂载一个回显端点，经 Wrap 输出握手拒绝的错误响应
+func newServer(t *testing.T, cors config.CORSConfig) string {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	up := ws.NewUpgrader(cors)
+	r := gin.New()
+	r.GET("/ws", middleware.Wrap(func(c *gin.Context) error {
+		conn, err := up.Upgrade(c)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}))
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+}
+
+func dial(url, origin string) (int, error) {
+	conn, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {origin}})
+	if conn != nil {
+		conn.Close()
+	}
+	if resp == nil {
+		return 0, err
+	}
+	return resp.StatusCode, err
+}
+
+func TestUpgradeAllowedOrigin(t *testing.T) {
+	url := newServer(t, config.CORSConfig{AllowOrigins: []string{allowedOrigin}, AllowCredentials: true})
+
+	status, err := dial(url, allowedOrigin)
+	if err != nil || status != http.StatusSwitchingProtocols {
+		t.Fatalf("status = %d, err = %v, want 101", status, err)
+	}
+}
+
+func TestUpgradeDisallowedOrigin(t *testing.T) {
+	cases := map[string]config.CORSConfig{
+		"不在白名单": {AllowOrigins: []string{allowedOrigin}},
+		"空白名单":  {},
+		"通配":    {AllowOrigins: []string{"*"}},
+	}
+
+	for name, cors := range cases {
+		t.Run(name, func(t *testing.T) {
+			url := newServer(t, cors)
+
+			status, err := dial(url, evilOrigin)
+			if err == nil || status != http.StatusForbidden {
+				t.Fatalf("status = %d, err = %v, want 403", status, err)
+			}
+		})
+	}
+}
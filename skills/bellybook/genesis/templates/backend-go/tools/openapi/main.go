@@ -0,0 +1,93 @@
+/**
+ * [INPUT]: 依赖 github.com/swaggo/swag/gen, github.com/getkin/kin-openapi
+ * [OUTPUT]: 无 - 文档生成工具
+ * [POS]: tools/openapi 解析 handler 的 swag 注释，产出 docs/openapi.json (OpenAPI 3)，由 magefile Docs 调用
+ * [PROTOCOL]: 变更时更新此头部，然后检查 CLAUDE.md
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/swaggo/swag/gen"
+)
+
+func main() {
+	root := flag.String("root", "../..", "项目根目录")
+	out := flag.String("out", "../../docs/openapi.json", "OpenAPI 3 输出文件")
+	flag.Parse()
+
+	// ════════════════════════════════════════════════════════════════════════
+	// Step 1: swag 解析注释，生成 Swagger 2.0 中间产物
+	// ════════════════════════════════════════════════════════════════════════
+	tmpDir, err := os.MkdirTemp("", "openapi-*")
+	if err != nil {
+		log.Fatalf("创建临时目录失败: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// 根目录仅有 mage 构建标签的 magefile.go，swag 以 go list 推导包路径时
+	// 需带上该标签，否则泛型类型 (如 dto.PageResponse[T]) 无法按导入路径解析
+	os.Setenv("GOFLAGS", strings.TrimSpace(os.Getenv("GOFLAGS")+" -tags=mage"))
+
+	err = gen.New().Build(&gen.Config{
+		SearchDir:          *root,
+		MainAPIFile:        "cmd/api/main.go",
+		OutputDir:          tmpDir,
+		OutputTypes:        []string{"json"},
+		ParseInternal:      true,
+		ParseDepth:         100,
+		PropNamingStrategy: "camelcase",
+		LeftTemplateDelim:  "{{",
+		RightTemplateDelim: "}}",
+		PackageName:        "docs",
+	})
+	if err != nil {
+		log.Fatalf("解析 swag 注释失败: %v", err)
+	}
+
+	// ════════════════════════════════════════════════════════════════════════
+	// Step 2: 转换为 OpenAPI 3
+	// ════════════════════════════════════════════════════════════════════════
+	raw, err := os.ReadFile(filepath.Join(tmpDir, "swagger.json"))
+	if err != nil {
+		log.Fatalf("读取 swagger.json 失败: %v", err)
+	}
+
+	var v2 openapi2.T
+	if err := json.Unmarshal(raw, &v2); err != nil {
+		log.Fatalf("解析 swagger.json 失败: %v", err)
+	}
+
+	v3, err := openapi2conv.ToV3(&v2)
+	if err != nil {
+		log.Fatalf("转换 OpenAPI 3 失败: %v", err)
+	}
+
+	// 未配置 host 时转换器不生成 servers，按 basePath 补齐相对地址
+	if len(v3.Servers) == 0 && v2.BasePath != "" {
+		v3.Servers = openapi3.Servers{{URL: v2.BasePath}}
+	}
+
+	doc, err := json.MarshalIndent(v3, "", "  ")
+	if err != nil {
+		log.Fatalf("序列化失败: %v", err)
+	}
+
+	// ════════════════════════════════════════════════════════════════════════
+	// Step 3: 写出
+	// ════════════════════════════════════════════════════════════════════════
+	if err := os.WriteFile(*out, append(doc, '\n'), 0o644); err != nil {
+		log.Fatalf("写入 %s 失败: %v", *out, err)
+	}
+	log.Printf("OpenAPI 文档已生成: %s", *out)
+}